@@ -3,20 +3,183 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
+
+	"postscript/ps"
 )
 
 func main() {
-	lexicalFlag := flag.Bool("lex", false, "Use lexical scoping") // for switching to lexical mode 
+	lexicalFlag := flag.Bool("lex", false, "Use lexical scoping") // for switching to lexical mode
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: postscript [-lex] [file.ps [args...]]")
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
-	mainInterpreter := CreateInterpreter()
-	mainInterpreter.lexicalMode = *lexicalFlag
+	// running a file: postscript file.ps [args]
+	if flag.NArg() > 0 {
+		fileInterpreter := ps.New(ps.Options{Lexical: *lexicalFlag, Args: flag.Args()[1:]})
+		os.Exit(runBatch(func() error { return fileInterpreter.RunFile(flag.Arg(0)) }, noLines))
+	}
+
+	mainInterpreter := ps.New(ps.Options{Lexical: *lexicalFlag})
+
+	// batch mode: input is piped in rather than typed, so it's run as one program
+	// it's streamed like a file, only the most recent lines are kept to show where an error happened
+	if !isTerminal(os.Stdin) {
+		recorder := &lineRecorder{reader: os.Stdin, number: 1}
+		os.Exit(runBatch(func() error { return mainInterpreter.RunReader("", recorder) }, recorder.line))
+	}
+
+	runREPL(mainInterpreter, *lexicalFlag)
+}
+
+// runs a whole program at once, printing any error to stderr and returning the exit code
+// lines gives the program's lines when it didn't come from a file, used to show where an error happened
+// note: the program is run without a name, so its positions have no source
+func runBatch(run func() error, lines lineSource) int {
+	err := run()
+	if err != nil {
+		printError(os.Stderr, err, lines, "")
+		return 1
+	}
+	return 0
+}
+
+// lineSource gives the text of a line of the program by its number (from 1), ok is false if it isn't known
+type lineSource func(number int) (text string, ok bool)
+
+// for programs whose lines aren't available
+func noLines(int) (string, bool) {
+	return "", false
+}
+
+// the lines of a program held in full, e.g. a line typed into the REPL
+func textLines(src string) lineSource {
+	lines := strings.Split(src, "\n")
+	return func(number int) (string, bool) {
+		if number < 1 || number > len(lines) {
+			return "", false
+		}
+		return lines[number-1], true
+	}
+}
+
+// how many complete lines a lineRecorder keeps
+const recentLines = 100
+
+// lineRecorder passes a program through while remembering the lines read most recently,
+// so an error can show its line without the whole program being kept in memory
+type lineRecorder struct {
+	reader  io.Reader
+	recent  []string // the last complete lines, oldest first
+	current []byte   // the line being read, not finished yet
+	number  int      // line number of current
+}
+
+func (r *lineRecorder) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	for _, ch := range p[:n] {
+		if ch != '\n' {
+			r.current = append(r.current, ch)
+			continue
+		}
+		if len(r.recent) == recentLines {
+			r.recent = r.recent[1:]
+		}
+		r.recent = append(r.recent, string(r.current))
+		r.current = r.current[:0]
+		r.number++
+	}
+	return n, err
+}
+
+// the text of a recently read line, a lineSource
+func (r *lineRecorder) line(number int) (string, bool) {
+	if number == r.number {
+		return string(r.current), true
+	}
+	index := len(r.recent) - (r.number - number)
+	if number > r.number || index < 0 {
+		return "", false
+	}
+	return r.recent[index], true
+}
+
+// prints an error followed by the source line it happened on with a caret under the offending token:
+//
+//	Error:  prog.ps:3:7: typecheck in add: ...
+//	    3 | 1 (a) add
+//	      |       ^
+//
+// lines are the lines of the input named source, the line is left out for errors in any other input
+func printError(w io.Writer, err error, lines lineSource, source string) {
+	fmt.Fprintln(w, "Error: ", err)
+
+	var psErr *ps.PSError
+	if !errors.As(err, &psErr) || !psErr.Pos.IsValid() {
+		return
+	}
+
+	// errors from run or a file given on the command line point into that file,
+	// others can be in an earlier REPL input or an executed string, whose text isn't at hand
+	switch {
+	case psErr.Pos.File != "":
+		contents, readErr := os.ReadFile(psErr.Pos.File)
+		if readErr != nil {
+			return
+		}
+		lines = textLines(string(contents))
+	case psErr.Pos.Source != source:
+		return
+	}
+
+	line, ok := lines(psErr.Pos.Line)
+	if !ok {
+		return
+	}
+	line = strings.TrimRight(line, "\r")
+	if psErr.Pos.Column > len(line)+1 {
+		return
+	}
+
+	// tabs are kept in front of the caret so it lines up with the source line
+	var caret strings.Builder
+	for _, ch := range []byte(line[:psErr.Pos.Column-1]) {
+		if ch == '\t' {
+			caret.WriteByte('\t')
+		} else {
+			caret.WriteByte(' ')
+		}
+	}
+	caret.WriteByte('^')
+
+	number := strconv.Itoa(psErr.Pos.Line)
+	gutter := strings.Repeat(" ", len(number))
+	fmt.Fprintf(w, " %s | %s\n", number, line)
+	fmt.Fprintf(w, " %s | %s\n", gutter, caret.String())
+}
+
+// reports whether f is an interactive terminal rather than a pipe or file
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// the interactive read-eval-print loop
+func runREPL(mainInterpreter *ps.Interpreter, lexical bool) {
 	// scoping mode for displaying on startup
 	scopingMode := "Dynamic scoping mode"
-	if *lexicalFlag {
+	if lexical {
 		scopingMode = "Lexical scoping mode"
 	}
 
@@ -27,36 +190,42 @@ func main() {
 	// scan std input
 	scanner := bufio.NewScanner(os.Stdin)
 
+	// each input is run under its own name so errors in procedures from earlier inputs aren't shown against this one
+	inputs := 0
+
 	// the actual REPL loop
 	for {
-		fmt.Printf("\nPS (%d)> ", mainInterpreter.opStack.StackCount()) // for displaying stack count
+		fmt.Printf("\nPS (%d)> ", mainInterpreter.StackCount()) // for displaying stack count
 		if !scanner.Scan() {
 			break
 		}
 
 		input := scanner.Text()
-		
+
 		// stylized help guide for available commands
 		if input == "commands" {
 			printREPLCommands()
 			continue
 		}
 
-		// input string as argument
-		tokenizer := CreateTokenizer(input)
-		tokens, err := tokenizer.Tokenize()
-		if err != nil {
-			fmt.Println("Error: ", err)
-			continue
+		// keep reading lines while a procedure, array or string is still open
+		for ps.Incomplete(input) {
+			fmt.Print("... ")
+			if !scanner.Scan() {
+				break
+			}
+			input += "\n" + scanner.Text()
 		}
 
-		err = mainInterpreter.Execute(tokens)
+		inputs++
+		source := fmt.Sprintf("%%stdin%d", inputs)
+		err := mainInterpreter.RunInput(source, input)
 		if err != nil {
-			fmt.Println("Error: ", err)
+			printError(os.Stdout, err, textLines(input), source)
 		}
 
-		// catching the quit flag 
-		if mainInterpreter.quit {
+		// catching the quit flag
+		if mainInterpreter.Quit() {
 			fmt.Println("\nExiting...")
 			break
 		}
@@ -88,6 +257,7 @@ func printWelcomeScreen() {
 	│   Washington State University - CptS 355 - Fall 2025        │
 	│   - Type 'quit' to exit                                     │
 	│   - To enable lexical scoping mode, run: 'go run . -lex'    │
+	│   - To run a file: 'go run . file.ps [args]'                │
 	│   - For command reference type 'commands'                   │
 	│                                                             │
 	╰─────────────────────────────────────────────────────────────╯
@@ -101,7 +271,7 @@ func printREPLCommands() {
 	│                   AVAILABLE COMMANDS                        │
 	╰─────────────────────────────────────────────────────────────╯
 
-	ARITHMETIC OPERATORS (12):
+	ARITHMETIC OPERATORS (22):
 	add          num1 num2 → sum           5 3 add = → 8
 	sub          num1 num2 → difference    10 3 sub = → 7
 	mul          num1 num2 → product       4 5 mul = → 20
@@ -110,58 +280,144 @@ func printREPLCommands() {
 	mod          int1 int2 → remainder     10 3 mod = → 1
 	abs          num → |num|               -5 abs = → 5
 	neg          num → -num                5 neg = → -5
-	sqrt         num → √num                16 sqrt = → 4
+	sqrt         num → √num                16 sqrt = → 4.0
 	ceiling      num → ⌈num⌉               3.2 ceiling = → 4.0
 	floor        num → ⌊num⌋               3.8 floor = → 3.0
 	round        num → rounded             3.5 round = → 4.0
+	truncate     num → truncated           -3.7 truncate = → -3.0
+	sin          angle → real              90 sin = → 1.0 (degrees)
+	cos          angle → real              0 cos = → 1.0
+	atan         num den → angle           1 -1 atan = → 135.0
+	exp          base exponent → real      2 10 exp = → 1024.0
+	ln           num → real                1 ln = → 0.0
+	log          num → real                100 log = → 2.0
+	rand         - → int                   Next random integer
+	srand        int → -                   42 srand (seed rand)
+	rrand        - → int                   Current rand state
 
-	STACK MANIPULATION (5):
+	STACK MANIPULATION (13):
 	dup          any → any any            5 dup → [5, 5]
 	pop          any → -                  5 pop → []
 	exch         a b → b a                1 2 exch → [2, 1]
 	clear        any... → -               Clear entire stack
 	count        any... → any... n        Push stack size
+	mark         - → mark                 Push a mark
+	counttomark  mark any... → mark any... n   Count items above mark
+	cleartomark  mark any... → -          Clear down to the mark
+	index        any... n → any... any    1 2 3 1 index → [1, 2, 3, 2]
+	copy         any... n → any... any... 1 2 2 copy → [1, 2, 1, 2]
+	roll         any... n j → any...      1 2 3 3 1 roll → [3, 1, 2]
+	pstack       any... → any...          Print stack (== form)
+	stack        any... → any...          Print stack (= form)
 
 	COMPARISON OPERATORS (6):
-	eq           a b → bool               5 5 eq = → true
-	ne           a b → bool               5 3 ne = → true
-	gt           a b → bool               5 3 gt = → true
+	eq           any any → bool           /abc (abc) eq = → true
+	ne           any any → bool           5 3 ne = → true
+	gt           a b → bool               (b) (a) gt = → true
 	ge           a b → bool               5 5 ge = → true
 	lt           a b → bool               3 5 lt = → true
 	le           a b → bool               3 5 le = → true
 
-	BOOLEAN OPERATORS (5):
-	and          bool1 bool2 → bool       true false and = → false
-	or           bool1 bool2 → bool       true false or = → true
-	not          bool → bool              true not = → false
+	BOOLEAN AND BITWISE OPERATORS (7):
+	and          bool/int bool/int → same true false and = → false
+	or           bool/int bool/int → same 12 10 or = → 14
+	xor          bool/int bool/int → same true true xor = → false
+	not          bool/int → same          true not = → false
+	bitshift     int shift → int          7 3 bitshift = → 56
 	true         - → true                 Push true
 	false        - → false                Push false
 
-	DICTIONARY OPERATIONS (6):
+	DICTIONARY OPERATIONS (19):
 	dict         int → dict               10 dict (create dict)
 	begin        dict → -                 Start using dictionary
 	end          - → -                    Stop using dictionary
 	def          key val → -              /x 5 def (define x=5)
 	length       dict → int               dict length = (entry count)
 	maxlength    dict → int               dict maxlength = (capacity)
+	load         key → value              /add load (look up without executing)
+	<< >>        mark k v ... → dict      << /a 1 /b 2 >> (build dict)
+	get          dict key → any           << /a 1 >> /a get = → 1
+	put          dict key any → -         currentdict /x 5 put
+	known        dict key → bool          << /a 1 >> /a known = → true
+	where        key → dict true | false  /add where (find defining dict)
+	store        key val → -              /x 5 store (replace where defined)
+	undef        dict key → -             currentdict /x undef
+	currentdict  - → dict                 Push the current dictionary
+	countdictstack - → int                Number of dictionaries on the stack
+	dictstack    array → subarray         5 array dictstack
+	cleardictstack - → -                  End every begun dictionary
+	systemdict / userdict  - → dict       The built-in dictionaries
 
-	STRING OPERATIONS (3):
+	STRING OPERATIONS (10):
+	string       int → str                5 string (5 zero bytes)
+	length       str → int                (hello) length = → 5
 	get          str idx → int            (hello) 0 get = → 104
-	getinterval  str idx cnt → substr     (hello) 1 3 getinterval =
-	putinterval  str1 idx str2 → str      (hello) 1 (XY) putinterval =
+	put          str idx int → -          /s (abc) def s 0 65 put s = → Abc
+	getinterval  str idx cnt → substr     (hello) 1 3 getinterval = → ell
+	putinterval  str1 idx str2 → -        /s (hello) def s 1 (XY) putinterval s =
+	copy         str1 str2 → substr       (ab) 5 string copy = → ab
+	search       str seek → post match pre true | str false
+	anchorsearch str seek → post match true | str false
+	forall       str proc → -             (ab) {=} forall (character codes)
+
+	ARRAY OPERATIONS (10):
+	[ ]          any... → array           [1 2 3] (build array)
+	array        int → array              3 array (array of nulls)
+	length       array → int              [1 2 3] length = → 3
+	get          array idx → any          [5 6 7] 1 get = → 6
+	put          array idx any → -        /a [1 2] def a 0 9 put
+	getinterval  array idx cnt → subarray [1 2 3] 1 2 getinterval
+	putinterval  array1 idx array2 → -    a 0 [7 8] putinterval
+	aload        array → any... array     [1 2] aload
+	astore       any... array → array     1 2 2 array astore
+	null         - → null                 Push null
 
-	FLOW CONTROL (6):
+	FLOW CONTROL (14):
 	if           bool proc → -            5 3 gt {(yes) print} if
 	ifelse       bool p1 p2 → -           true {1} {2} ifelse exec =
-	for          j k l proc → -           0 1 5 {} for (0 to 5)
+	for          j k l proc → -           0 1 5 {} for (0 to 5), 0 0.5 1 {} for
 	repeat       n proc → -               3 {(hi) print} repeat
+	loop         proc → -                 {(hi) print} loop (until exit)
+	exit         - → -                    Leave the innermost loop
+	forall       arr/str/dict proc → -    [1 2 3] {=} forall
 	exec         proc → -                 {1 2 add} exec = → 3
+	bind         proc → proc              {1 2 add} bind (resolve operators now)
+	stop         - → -                    Unwind to the nearest stopped
+	stopped      proc → bool              {1 (a) add} stopped = → true
+	execstack    array → subarray         10 array execstack (copy exec stack)
+	countexecstack - → int                Number of frames on the exec stack
 	quit         - → -                    Exit interpreter
 
-	I/O OPERATIONS (3):
+	SCOPING (3):
+	lexbind      proc → proc              {x} lexbind (make lexically scoped)
+	setlexical   bool → -                 true setlexical (scoping of new procs)
+	currentlexical - → bool               currentlexical = → false
+
+	ACCESS (5):
+	readonly     obj → obj                [1] readonly (can't be changed)
+	executeonly  array/str → obj          {1} executeonly (can only be run)
+	noaccess     obj → obj                (s) noaccess (can't be used)
+	rcheck       obj → bool               [1] readonly rcheck = → true
+	wcheck       obj → bool               systemdict wcheck = → false
+
+	CONVERSION (9):
+	type         any → name               3 type = → integertype
+	cvx          any → any                /x cvx (make executable)
+	cvlit        any → any                {1 2} cvlit (make literal)
+	xcheck       any → bool               {1} xcheck = → true
+	cvi          num/str → int            3.7 cvi = → 3
+	cvr          num/str → real           5 cvr = → 5.0
+	cvn          str → name               (abc) cvn == → /abc
+	cvs          any str → substr         42 10 string cvs = → 42
+	cvrs         num radix str → substr   255 16 10 string cvrs = → FF
+
+	I/O OPERATIONS (6):
 	print        str → -                  (hello) print
 	=            any → -                  42 = (print with newline)
 	==           any → -                  (test) == (show as (test))
+	run          str → -                  (file.ps) run (execute a file)
+	currentfile  - → file                 File the program is being read from
+	token        file/str → any true      currentfile token 42 pop = → 42
 
 	SPECIAL COMMANDS:
 	commands     Show this command list
@@ -176,7 +432,7 @@ func printREPLCommands() {
 
 	Procedures:
 		/square {dup mul} def
-		5 square exec =       Prints 25
+		5 square =            Prints 25
 
 	Conditionals:
 		5 3 gt {(bigger)} {(smaller)} ifelse print
@@ -187,7 +443,7 @@ func printREPLCommands() {
 	Scoping:
 		/x 1 def
 		/show {x =} def
-		10 dict begin /x 2 def show exec end
+		10 dict begin /x 2 def show end
 		(Dynamic: 2, Lexical: 1)
 
 	╰─────────────────────────────────────────────────────────────╯
@@ -0,0 +1,133 @@
+package ps
+
+// ======================================== access attribute operators
+
+// the access attribute of obj, ok is false for objects that don't have one
+func accessOf(obj PSConstant) (accessLevel, bool) {
+	switch val := obj.(type) {
+	case PSArray:
+		return val.access, true
+	case PSString:
+		return val.access, true
+	case *PSDict:
+		return val.access, true
+	default:
+		return unlimitedAccess, false
+	}
+}
+
+// invalidaccess unless the contents of obj can be read, objects without an access attribute always can
+func checkRead(obj PSConstant, command string) error {
+	if access, _ := accessOf(obj); !access.canRead() {
+		return invalidAccess("%s can't read a %s object", command, accessName(access))
+	}
+	return nil
+}
+
+// invalidaccess unless the contents of obj can be changed
+func checkWrite(obj PSConstant, command string) error {
+	if access, _ := accessOf(obj); !access.canWrite() {
+		return invalidAccess("%s can't change a %s object", command, accessName(access))
+	}
+	return nil
+}
+
+// the operator that gives an access level, used in error messages
+func accessName(access accessLevel) string {
+	switch access {
+	case readOnlyAccess:
+		return "readonly"
+	case executeOnlyAccess:
+		return "executeonly"
+	case noAccess:
+		return "noaccess"
+	default:
+		return "unlimited"
+	}
+}
+
+// reduces the access of the object on top of the stack to level
+// arrays and strings are changed for this reference only, dictionaries for every reference
+func reduceAccess(i *Interpreter, level accessLevel, command string) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	current, ok := accessOf(val)
+	if !ok {
+		return typeCheck("%s requires an array, string or dictionary", command)
+	}
+	// access can only be taken away, never given back
+	if current > level {
+		return invalidAccess("%s can't raise the access of a %s object", command, accessName(current))
+	}
+	// a dictionary's access is shared, so once it's read only nobody can take more away, e.g. from systemdict
+	if isDict(val) && current != level && !current.canWrite() {
+		return invalidAccess("%s can't change the access of a %s dictionary", command, accessName(current))
+	}
+
+	switch obj := val.(type) {
+	case PSArray:
+		obj.access = level
+		i.opStack.Push(obj)
+	case PSString:
+		obj.access = level
+		i.opStack.Push(obj)
+	case *PSDict:
+		obj.access = level
+		i.opStack.Push(obj)
+	}
+	return nil
+}
+
+// opReadOnly makes an object read only, so it can be read and executed but not changed
+func opReadOnly(i *Interpreter) error {
+	return reduceAccess(i, readOnlyAccess, "readonly")
+}
+
+// opExecuteOnly makes an array or string execute only, so it can be executed but not read or changed
+func opExecuteOnly(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	if val, _ := i.opStack.Peek(); isDict(val) {
+		return typeCheck("executeonly requires an array or string")
+	}
+	return reduceAccess(i, executeOnlyAccess, "executeonly")
+}
+
+// opNoAccess makes an object inaccessible, it can't be read, changed or executed
+func opNoAccess(i *Interpreter) error {
+	return reduceAccess(i, noAccess, "noaccess")
+}
+
+// opRcheck pushes whether the contents of an array, string or dictionary can be read
+func opRcheck(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	access, ok := accessOf(val)
+	if !ok {
+		return typeCheck("rcheck requires an array, string or dictionary")
+	}
+	i.opStack.Push(access.canRead())
+	return nil
+}
+
+// opWcheck pushes whether the contents of an array, string or dictionary can be changed
+func opWcheck(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	access, ok := accessOf(val)
+	if !ok {
+		return typeCheck("wcheck requires an array, string or dictionary")
+	}
+	i.opStack.Push(access.canWrite())
+	return nil
+}
@@ -0,0 +1,145 @@
+package ps
+
+import (
+	"testing"
+)
+
+func TestAccessChecks(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"new array readable", "[1] rcheck", true},
+		{"new array writable", "[1] wcheck", true},
+		{"readonly array readable", "[1] readonly rcheck", true},
+		{"readonly array not writable", "[1] readonly wcheck", false},
+		{"executeonly array not readable", "{1} executeonly rcheck", false},
+		{"executeonly string not writable", "(a) executeonly wcheck", false},
+		{"noaccess string not readable", "(a) noaccess rcheck", false},
+		{"readonly dict not writable", "1 dict readonly wcheck", false},
+		{"noaccess dict not readable", "1 dict noaccess rcheck", false},
+		{"systemdict is read only", "systemdict wcheck", false},
+		{"systemdict can be read", "systemdict rcheck", true},
+		{"userdict is writable", "userdict wcheck", true},
+		{"errordict is writable", "errordict wcheck", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := runTest(t, test.input)
+			compareStackTop(t, i, test.expected)
+		})
+	}
+}
+
+func TestAccessAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected PSConstant
+	}{
+		{"readonly array can be read", "[1 2] readonly 1 get", 2},
+		{"readonly string can be read", "(abc) readonly 0 get", 97},
+		{"readonly dict can be read", "<< /a 1 >> readonly /a get", 1},
+		{"readonly dict can be begun", "<< /a 1 >> readonly begin a end", 1},
+		{"executeonly procedure runs", "{1 2 add} executeonly exec", 3},
+		{"executeonly procedure runs with if", "true {6} executeonly if", 6},
+		{"length of readonly string", "(abc) readonly length", 3},
+		{"executeonly string runs", "(3 4 mul) cvx executeonly exec", 12},
+		{"readonly procedure runs", "/f {5} readonly def f", 5},
+		{"operators can still be shadowed", "/add {mul} def 3 4 add", 12},
+		{"array access is per reference", "/a [1 2] def a readonly pop a 0 9 put a 0 get", 9},
+		{"dict access is shared", "/d 1 dict def d readonly pop d wcheck", false},
+		{"substring keeps access", "(abc) readonly 0 2 getinterval wcheck", false},
+		{"subarray keeps access", "[1 2 3] readonly 0 2 getinterval wcheck", false},
+		{"cvx keeps access", "[1] readonly cvx wcheck", false},
+		{"reducing access again", "[1] readonly readonly noaccess rcheck", false},
+		{"readonly dict made readonly again", "1 dict readonly readonly wcheck", false},
+		{"systemdict still readable after failed noaccess", "systemdict {noaccess} stopped pop pop systemdict rcheck", true},
+		{"cvs of noaccess array", "[1] noaccess 20 string cvs", "--nostringval--"},
+		{"bind leaves readonly procedures alone", "/add {mul} def {add} readonly bind 0 get type", PSExecName("nametype")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := runTest(t, test.input)
+			compareStackTop(t, i, test.expected)
+		})
+	}
+}
+
+func TestAccessErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"put into readonly array", "[1] readonly 0 2 put", "invalidaccess"},
+		{"put into readonly string", "(a) readonly 0 65 put", "invalidaccess"},
+		{"put into readonly dict", "1 dict readonly /a 1 put", "invalidaccess"},
+		{"putinterval into readonly string", "(abc) readonly 0 (x) putinterval", "invalidaccess"},
+		{"putinterval into readonly array", "[1 2] readonly 0 [3] putinterval", "invalidaccess"},
+		{"copy into readonly string", "(a) (bc) readonly copy", "invalidaccess"},
+		{"astore into readonly array", "1 [0] readonly astore", "invalidaccess"},
+		{"cvs into readonly string", "1 (abc) readonly cvs", "invalidaccess"},
+		{"get from executeonly array", "{1} executeonly 0 get", "invalidaccess"},
+		{"get from noaccess dict", "<< /a 1 >> noaccess /a get", "invalidaccess"},
+		{"known in noaccess dict", "<< /a 1 >> noaccess /a known", "invalidaccess"},
+		{"aload of executeonly array", "[1] executeonly aload", "invalidaccess"},
+		{"forall over noaccess string", "(ab) noaccess {} forall", "invalidaccess"},
+		{"search in noaccess string", "(ab) noaccess (a) search", "invalidaccess"},
+		{"token from executeonly string", "(1 2) executeonly token", "invalidaccess"},
+		{"begin noaccess dict", "1 dict noaccess begin", "invalidaccess"},
+		{"executing noaccess procedure", "{1} noaccess exec", "invalidaccess"},
+		{"executing noaccess string", "(1) cvx noaccess exec", "invalidaccess"},
+		{"if with noaccess procedure", "true {4} noaccess if", "invalidaccess"},
+		{"ifelse with noaccess procedure", "false {4} {5} noaccess ifelse", "invalidaccess"},
+		{"repeat with noaccess procedure", "2 {4} noaccess repeat", "invalidaccess"},
+		{"for with noaccess procedure", "1 1 2 {} noaccess for", "invalidaccess"},
+		{"loop with noaccess procedure", "{exit} noaccess loop", "invalidaccess"},
+		{"forall with noaccess procedure", "[1] {} noaccess forall", "invalidaccess"},
+		{"length of noaccess string", "(abc) noaccess length", "invalidaccess"},
+		{"length of executeonly array", "{1 2} executeonly length", "invalidaccess"},
+		{"length of noaccess dict", "1 dict noaccess length", "invalidaccess"},
+		{"def into systemdict", "systemdict begin /x 1 def", "invalidaccess"},
+		{"put into systemdict", "systemdict /add {} put", "invalidaccess"},
+		{"store over an operator", "/add {} store", "invalidaccess"},
+		{"undef from systemdict", "systemdict /add undef", "invalidaccess"},
+		{"raising access", "[1] executeonly readonly", "invalidaccess"},
+		{"noaccess systemdict", "systemdict noaccess", "invalidaccess"},
+		{"noaccess readonly dict", "1 dict readonly noaccess", "invalidaccess"},
+		{"cvs of noaccess string", "(abc) noaccess 3 string cvs", "invalidaccess"},
+		{"cvs of executeonly string", "(abc) executeonly 3 string cvs", "invalidaccess"},
+		{"cvn of noaccess string", "(abc) noaccess cvn", "invalidaccess"},
+		{"= of noaccess string", "(abc) noaccess =", "invalidaccess"},
+		{"== of executeonly string", "(abc) executeonly ==", "invalidaccess"},
+		{"== of noaccess array", "[1] noaccess ==", "invalidaccess"},
+		{"print of noaccess string", "(abc) noaccess print", "invalidaccess"},
+		{"executeonly dict", "1 dict executeonly", "typecheck"},
+		{"readonly integer", "1 readonly", "typecheck"},
+		{"rcheck name", "/a rcheck", "typecheck"},
+		{"wcheck nothing", "wcheck", "stackunderflow"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := CreateInterpreter()
+			err := i.Run(test.input)
+
+			psErr, ok := err.(*PSError)
+			if !ok || psErr.Name != test.expected {
+				t.Errorf("expected %s, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestSystemDictUnchangedAfterError(t *testing.T) {
+	i := CreateInterpreter()
+	i.Run("systemdict /add {} put")
+
+	if _, ok := i.systemDict.items["add"].(*PSOperator); !ok {
+		t.Error("Expected systemdict add to still be an operator")
+	}
+}
@@ -0,0 +1,504 @@
+package ps
+
+import (
+	"math"
+)
+
+// PostScript integers are 32 bit, integer results outside this range are promoted to reals
+const (
+	maxPSInt = math.MaxInt32
+	minPSInt = math.MinInt32
+)
+
+// returns an integer result as an integer if it fits, otherwise as a real
+func intOrReal(result int) PSConstant {
+	if result > maxPSInt || result < minPSInt {
+		return float64(result)
+	}
+	return result
+}
+
+// arithmetic operators ====================================================
+
+// opAdd adds 2 operands
+func opAdd(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	y, _ := i.opStack.Pop()
+	x, _ := i.opStack.Pop()
+
+	// int + int stays an integer unless it overflows
+	xInt, okX := x.(int)
+	yInt, okY := y.(int)
+	if okX && okY {
+		i.opStack.Push(intOrReal(xInt + yInt))
+		return nil
+	}
+
+	xToNum, err := convertToNumber(x)
+	if err != nil {
+		return typeCheck("operand must be a number")
+	}
+
+	yToNum, err := convertToNumber(y)
+	if err != nil {
+		return typeCheck("operand must be a number")
+	}
+
+	result := xToNum + yToNum
+	i.opStack.Push(result)
+
+	return nil
+}
+
+// opSub subtracts one operand from the other
+func opSub(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	y, _ := i.opStack.Pop()
+	x, _ := i.opStack.Pop()
+
+	// int - int stays an integer unless it overflows
+	xInt, okX := x.(int)
+	yInt, okY := y.(int)
+	if okX && okY {
+		i.opStack.Push(intOrReal(xInt - yInt))
+		return nil
+	}
+
+	xToNum, err := convertToNumber(x)
+	if err != nil {
+		return typeCheck("operand must be a number")
+	}
+
+	yToNum, err := convertToNumber(y)
+	if err != nil {
+		return typeCheck("operand must be a number")
+	}
+
+	result := xToNum - yToNum
+	i.opStack.Push(result)
+
+	return nil
+}
+
+// opMul multiplies one operand by the other
+func opMul(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	y, _ := i.opStack.Pop()
+	x, _ := i.opStack.Pop()
+
+	// int * int stays an integer unless it overflows
+	// note: two 32 bit integers always fit in a 64 bit product so the check is safe
+	xInt, okX := x.(int)
+	yInt, okY := y.(int)
+	if okX && okY {
+		i.opStack.Push(intOrReal(xInt * yInt))
+		return nil
+	}
+
+	xToNum, err := convertToNumber(x)
+	if err != nil {
+		return typeCheck("operand must be a number")
+	}
+
+	yToNum, err := convertToNumber(y)
+	if err != nil {
+		return typeCheck("operand must be a number")
+	}
+
+	result := xToNum * yToNum
+	i.opStack.Push(result)
+
+	return nil
+}
+
+// opDiv divides one operand by the other, the result is always a real
+func opDiv(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	y, _ := i.opStack.Pop()
+	x, _ := i.opStack.Pop()
+
+	xToNum, err := convertToNumber(x)
+	if err != nil {
+		return typeCheck("operand must be a number")
+	}
+
+	yToNum, err := convertToNumber(y)
+	if err != nil {
+		return typeCheck("operand must be a number")
+	}
+
+	if yToNum == 0 {
+		return newPSError("undefinedresult", "division by zero")
+	}
+
+	result := xToNum / yToNum
+	i.opStack.Push(result)
+
+	return nil
+}
+
+// integer division operators =========================================
+
+// opIntdiv performs integer division, truncating towards zero
+func opIntdiv(i *Interpreter) error {
+
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	y, _ := i.opStack.Pop()
+	x, _ := i.opStack.Pop()
+
+	xInt, okX := x.(int)
+	yInt, okY := y.(int)
+	if !okX || !okY {
+		return typeCheck("idiv requires two integers")
+	}
+
+	if yInt == 0 {
+		return newPSError("undefinedresult", "division by zero")
+	}
+
+	// go integer division already truncates towards zero like PostScript
+	i.opStack.Push(intOrReal(xInt / yInt))
+
+	return nil
+}
+
+// opMod performs modulo operation, the result takes the sign of the dividend
+func opMod(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	y, _ := i.opStack.Pop()
+	x, _ := i.opStack.Pop()
+
+	xInt, okX := x.(int)
+	yInt, okY := y.(int)
+	if !okX || !okY {
+		return typeCheck("mod requires two integers")
+	}
+
+	if yInt == 0 {
+		return newPSError("undefinedresult", "division by zero")
+	}
+
+	result := xInt % yInt
+	i.opStack.Push(result)
+
+	return nil
+}
+
+// unary operations =================================================
+
+// opSqrt calculates square root
+func opSqrt(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	x, _ := i.opStack.Pop()
+
+	xToNum, err := convertToNumber(x)
+	if err != nil {
+		return typeCheck("operand must be a number")
+	}
+
+	if xToNum < 0 {
+		return rangeCheck("sqrt of a negative number")
+	}
+
+	result := math.Sqrt(xToNum)
+
+	i.opStack.Push(result)
+	return nil
+}
+
+// opAbs takes absolute value of given number, keeping its type
+func opAbs(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+
+	val, _ := i.opStack.Pop()
+
+	if num, ok := val.(int); ok {
+		if num < 0 {
+			num = -num
+		}
+		i.opStack.Push(intOrReal(num))
+		return nil
+	}
+
+	num, err := convertToNumber(val)
+	if err != nil {
+		return typeCheck("operand must be a number")
+	}
+
+	if num < 0 {
+		num = -num
+	}
+
+	i.opStack.Push(num)
+
+	return nil
+}
+
+// opNeg negates a number, keeping its type
+func opNeg(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+
+	val, _ := i.opStack.Pop()
+
+	if num, ok := val.(int); ok {
+		i.opStack.Push(intOrReal(-num))
+		return nil
+	}
+
+	num, err := convertToNumber(val)
+	if err != nil {
+		return typeCheck("operand must be a number")
+	}
+
+	i.opStack.Push(-num)
+	return nil
+}
+
+// rounding operations =============================================
+// integers are already whole so they are returned unchanged, reals stay reals
+
+// opCeil returns ceiling of number
+func opCeil(i *Interpreter) error {
+	return roundWith(i, math.Ceil)
+}
+
+// opFloor returns floor of number
+func opFloor(i *Interpreter) error {
+	return roundWith(i, math.Floor)
+}
+
+// opRound rounds to nearest integer, halves go towards the greater value like PostScript, so -3.5 gives -3.0
+func opRound(i *Interpreter) error {
+	return roundWith(i, roundHalfUp)
+}
+
+// rounds to the nearest integer, halves upwards
+// note: not math.Floor(x + 0.5), which gives 1 for the real just below 0.5 because the sum rounds up
+func roundHalfUp(x float64) float64 {
+	floor := math.Floor(x)
+	if x-floor >= 0.5 {
+		return floor + 1
+	}
+	return floor
+}
+
+// shared body of the rounding operators
+func roundWith(i *Interpreter, round func(float64) float64) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+
+	x, _ := i.opStack.Pop()
+
+	switch val := x.(type) {
+	case int:
+		i.opStack.Push(val)
+	case float64:
+		i.opStack.Push(round(val))
+	default:
+		return typeCheck("operand must be a number")
+	}
+
+	return nil
+}
+
+// opTruncate removes the fractional part of a number, rounding towards zero
+func opTruncate(i *Interpreter) error {
+	return roundWith(i, math.Trunc)
+}
+
+// trigonometry ===================================================
+// angles are in degrees like everywhere else in PostScript
+
+// opSin pushes the sine of an angle
+func opSin(i *Interpreter) error {
+	return trigWith(i, math.Sin)
+}
+
+// opCos pushes the cosine of an angle
+func opCos(i *Interpreter) error {
+	return trigWith(i, math.Cos)
+}
+
+// shared body of sin and cos
+// results for whole multiples of 90 degrees are made exact, so 180 sin is 0.0 rather than 1.2e-16
+func trigWith(i *Interpreter, fn func(float64) float64) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	x, _ := i.opStack.Pop()
+
+	angle, err := convertToNumber(x)
+	if err != nil {
+		return typeCheck("operand must be a number")
+	}
+
+	angle = math.Mod(angle, 360)
+	result := fn(angle * math.Pi / 180)
+	if angle == math.Trunc(angle) && int(angle)%90 == 0 {
+		result = math.Round(result)
+		if result == 0 {
+			result = 0 // not -0.0
+		}
+	}
+
+	i.opStack.Push(result)
+	return nil
+}
+
+// opAtan pushes the angle in degrees, between 0 and 360, whose tangent is num/den
+// num den atan → angle, the signs of num and den pick the quadrant
+func opAtan(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+	d, _ := i.opStack.Pop()
+	n, _ := i.opStack.Pop()
+
+	num, errNum := convertToNumber(n)
+	den, errDen := convertToNumber(d)
+	if errNum != nil || errDen != nil {
+		return typeCheck("operand must be a number")
+	}
+	if num == 0 && den == 0 {
+		return newPSError("undefinedresult", "atan of 0 / 0")
+	}
+
+	angle := math.Atan2(num, den) * 180 / math.Pi
+	if angle < 0 {
+		angle += 360
+	}
+
+	i.opStack.Push(angle)
+	return nil
+}
+
+// exponents and logarithms =====================================
+
+// opExp raises base to the power exponent, the result is always a real
+func opExp(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+	e, _ := i.opStack.Pop()
+	b, _ := i.opStack.Pop()
+
+	base, errBase := convertToNumber(b)
+	exponent, errExponent := convertToNumber(e)
+	if errBase != nil || errExponent != nil {
+		return typeCheck("operand must be a number")
+	}
+
+	result := math.Pow(base, exponent)
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return newPSError("undefinedresult", "%s raised to %s", formatText(b), formatText(e))
+	}
+
+	i.opStack.Push(result)
+	return nil
+}
+
+// opLn pushes the natural logarithm of a positive number
+func opLn(i *Interpreter) error {
+	return logWith(i, math.Log)
+}
+
+// opLog pushes the base 10 logarithm of a positive number
+func opLog(i *Interpreter) error {
+	return logWith(i, math.Log10)
+}
+
+// shared body of ln and log
+func logWith(i *Interpreter, fn func(float64) float64) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	x, _ := i.opStack.Pop()
+
+	num, err := convertToNumber(x)
+	if err != nil {
+		return typeCheck("operand must be a number")
+	}
+	if num <= 0 {
+		return rangeCheck("logarithm of a number that isn't positive")
+	}
+
+	i.opStack.Push(fn(num))
+	return nil
+}
+
+// random numbers ================================================
+// the generator is the Park and Miller minimal standard one other PostScript interpreters use,
+// so a program seeded with srand produces the same numbers everywhere
+
+const (
+	randMultiplier = 16807
+	randModulus    = math.MaxInt32 // 2^31 - 1
+	randQuotient   = randModulus / randMultiplier
+	randRemainder  = randModulus % randMultiplier
+)
+
+// opRand pushes the next random integer, between 0 and 2^31 - 1
+func opRand(i *Interpreter) error {
+	// Schrage's method, computes multiplier * state mod modulus without overflowing 32 bits
+	state := randMultiplier*(i.randState%randQuotient) - randRemainder*(i.randState/randQuotient)
+	if state <= 0 {
+		state += randModulus
+	}
+	i.randState = state
+
+	i.opStack.Push(state)
+	return nil
+}
+
+// opSrand seeds the random number generator with an integer
+// seeds outside 1 to 2^31 - 2 are brought into that range, so every seed gives a usable state
+func opSrand(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	seed, ok := val.(int)
+	if !ok {
+		return typeCheck("srand requires an integer")
+	}
+
+	if seed < 1 {
+		seed = -(seed % (randModulus - 1)) + 1
+	} else if seed > randModulus-1 {
+		seed = randModulus - 1
+	}
+	i.randState = seed
+	return nil
+}
+
+// opRrand pushes the current state of the random number generator, which srand can restore later
+func opRrand(i *Interpreter) error {
+	i.opStack.Push(i.randState)
+	return nil
+}
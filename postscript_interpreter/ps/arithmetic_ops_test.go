@@ -1,4 +1,4 @@
-package main
+package ps
 
 import (
 	"testing"
@@ -20,7 +20,7 @@ func TestOpAdd(t *testing.T) {
 	tests := []struct {
 		name     string  // name of the test being run
 		x, y     any     // operands
-		expected float64 // expected value
+		expected any // expected value
 	}{
 		{"positive integer addition", 3, 4, 7},
 		{"negative integer addition", -5, -3, -8},
@@ -28,13 +28,14 @@ func TestOpAdd(t *testing.T) {
 		{"zero addition", 5, 0, 5},
 		{"floating point addition", 3.2, 2.5, 5.7},
 		{"floating point and integer addition", 5, 2.5, 7.5},
+		{"integer overflow promotes to real", 2147483647, 1, 2147483648.0},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.x)
-			testInterpreter.opStack.Push(test.y)
+			testInterpreter.opStack.Push(testValue(test.x))
+			testInterpreter.opStack.Push(testValue(test.y))
 
 			// error present
 			err := opAdd(testInterpreter)
@@ -55,20 +56,21 @@ func TestOpSub(t *testing.T) {
 	tests := []struct {
 		name     string
 		x, y     any
-		expected float64
+		expected any
 	}{
 		{"positive integer subtraction", 10, 3, 7},
 		{"mixed sign integer subtraction", 3, 10, -7},
 		{"negative integer subtraction", -5, -3, -2},
-		{"zero subtraction", 5, 0, 5.0},
+		{"zero subtraction", 5, 0, 5},
 		{"floating point subtraction", 5.7, 2.5, 3.2},
+		{"integer underflow promotes to real", -2147483648, 1, -2147483649.0},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.x)
-			testInterpreter.opStack.Push(test.y)
+			testInterpreter.opStack.Push(testValue(test.x))
+			testInterpreter.opStack.Push(testValue(test.y))
 
 			// error occurred
 			err := opSub(testInterpreter)
@@ -89,18 +91,19 @@ func TestOpMul(t *testing.T) {
 	tests := []struct {
 		name     string
 		x, y     any
-		expected float64
+		expected any
 	}{
 		{"integer multiplication", 5, 5, 25},
 		{"mixed sign multiplication", -2, 5, -10},
 		{"negative integer multiplication", -1, -5, 5},
 		{"floating point multiplication", 2.5, 2, 5.0},
+		{"integer overflow promotes to real", 65536, 65536, 4294967296.0},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.x)
-			testInterpreter.opStack.Push(test.y)
+			testInterpreter.opStack.Push(testValue(test.x))
+			testInterpreter.opStack.Push(testValue(test.y))
 			err := opMul(testInterpreter)
 
 			//error
@@ -134,8 +137,8 @@ func TestOpDiv(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.x)
-			testInterpreter.opStack.Push(test.y)
+			testInterpreter.opStack.Push(testValue(test.x))
+			testInterpreter.opStack.Push(testValue(test.y))
 
 			// error
 			err := opDiv(testInterpreter)
@@ -177,14 +180,13 @@ func TestOpIntdiv(t *testing.T) {
 		{"negative dividend", -10, 3, -3},
 		{"negative divisor", 10, -3, -3},
 		{"both negative", -10, -3, 3},
-		{"float inputs", 10.8, 3.2, 3},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.x)
-			testInterpreter.opStack.Push(test.y)
+			testInterpreter.opStack.Push(testValue(test.x))
+			testInterpreter.opStack.Push(testValue(test.y))
 
 			// error
 			err := opIntdiv(testInterpreter)
@@ -201,6 +203,18 @@ func TestOpIntdiv(t *testing.T) {
 	}
 }
 
+func TestOpIntdivRequiresIntegers(t *testing.T) {
+	testInterpreter := CreateInterpreter()
+	testInterpreter.opStack.Push(10.8)
+	testInterpreter.opStack.Push(3.2)
+
+	err := opIntdiv(testInterpreter)
+	psErr, ok := err.(*PSError)
+	if !ok || psErr.Name != "typecheck" {
+		t.Errorf("expected typecheck error, got %v", err)
+	}
+}
+
 func TestOpIntdivByZero(t *testing.T) {
 	testInterpreter := CreateInterpreter()
 	testInterpreter.opStack.Push(10)
@@ -224,14 +238,15 @@ func TestOpMod(t *testing.T) {
 		{"small remainder", 7, 2, 1},
 		{"larger remainder", 8, 3, 2},
 		{"negative dividend", -10, 3, -1},
+		{"negative divisor", 10, -3, 1},
 		{"large numbers", 100, 7, 2},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.x)
-			testInterpreter.opStack.Push(test.y)
+			testInterpreter.opStack.Push(testValue(test.x))
+			testInterpreter.opStack.Push(testValue(test.y))
 
 			// error
 			err := opMod(testInterpreter)
@@ -266,19 +281,20 @@ func TestOpAbs(t *testing.T) {
 	tests := []struct {
 		name     string
 		input    any
-		expected float64
+		expected any
 	}{
 		{"positive integer", 5, 5},
 		{"negative integer", -5, 5},
 		{"zero", 0, 0},
 		{"positive float", 3.14, 3.14},
 		{"negative float", -3.14, 3.14},
+		{"smallest integer promotes to real", -2147483648, 2147483648.0},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.input)
+			testInterpreter.opStack.Push(testValue(test.input))
 
 			// error 
 			err := opAbs(testInterpreter)
@@ -299,19 +315,20 @@ func TestOpNeg(t *testing.T) {
 	tests := []struct {
 		name     string
 		input    any
-		expected float64
+		expected any
 	}{
 		{"positive integer", 5, -5},
 		{"negative integer", -5, 5},
-		{"zero", 0, 0.0},
+		{"zero", 0, 0},
 		{"positive float", 3.14, -3.14},
 		{"negative float", -3.14, 3.14},
+		{"smallest integer promotes to real", -2147483648, 2147483648.0},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.input)
+			testInterpreter.opStack.Push(testValue(test.input))
 
 			// error
 			err := opNeg(testInterpreter)
@@ -346,7 +363,7 @@ func TestOpSqrt(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.input)
+			testInterpreter.opStack.Push(testValue(test.input))
 
 			// error
 			err := opSqrt(testInterpreter)
@@ -384,7 +401,7 @@ func TestOpCeiling(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.input)
+			testInterpreter.opStack.Push(testValue(test.input))
 
 			// error 
 			err := opCeil(testInterpreter)
@@ -419,7 +436,7 @@ func TestOpFloor(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.input)
+			testInterpreter.opStack.Push(testValue(test.input))
 
 			// error
 			err := opFloor(testInterpreter)
@@ -436,6 +453,30 @@ func TestOpFloor(t *testing.T) {
 	}
 }
 
+func TestRoundingKeepsIntegers(t *testing.T) {
+	ops := []struct {
+		name string
+		op   func(*Interpreter) error
+	}{
+		{"ceiling", opCeil},
+		{"floor", opFloor},
+		{"round", opRound},
+	}
+
+	for _, test := range ops {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := CreateInterpreter()
+			testInterpreter.opStack.Push(7)
+
+			err := test.op(testInterpreter)
+			if err != nil {
+				t.Fatalf("unexpected %s error: %v", test.name, err)
+			}
+			compareStackTop(t, testInterpreter, 7)
+		})
+	}
+}
+
 func TestOpRound(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -448,14 +489,17 @@ func TestOpRound(t *testing.T) {
 		{"already integer", 3.0, 3},
 		{"negative round down", -4.8, -5},
 		{"negative round up", -4.2, -4},
-		{"negative at half", -4.5, -5},
+		{"negative at half rounds up", -4.5, -4},
+		{"negative at half rounds up again", -3.5, -3},
+		{"negative half to zero", -0.5, 0},
 		{"positive at half", 0.5, 1},
+		{"just below half", 0.49999999999999994, 0},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.input)
+			testInterpreter.opStack.Push(testValue(test.input))
 
 			// error
 			err := opRound(testInterpreter)
@@ -491,6 +535,14 @@ func TestArithmeticStackUnderflow(t *testing.T) {
 		{"ceiling underflow", opCeil},
 		{"floor underflow", opFloor},
 		{"round underflow", opRound},
+		{"truncate underflow", opTruncate},
+		{"sin underflow", opSin},
+		{"cos underflow", opCos},
+		{"atan underflow", opAtan},
+		{"exp underflow", opExp},
+		{"ln underflow", opLn},
+		{"log underflow", opLog},
+		{"srand underflow", opSrand},
 	}
 
 	for _, test := range tests {
@@ -504,3 +556,132 @@ func TestArithmeticStackUnderflow(t *testing.T) {
 		})
 	}
 }
+
+func TestOpTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected PSConstant
+	}{
+		{"positive real", "3.7 truncate", 3.0},
+		{"negative real towards zero", "-3.7 truncate", -3.0},
+		{"integer unchanged", "5 truncate", 5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestTrigonometry(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected float64
+	}{
+		{"sin 0", "0 sin", 0.0},
+		{"sin 90", "90 sin", 1.0},
+		{"sin 180 is exact", "180 sin", 0.0},
+		{"sin 270", "270 sin", -1.0},
+		{"sin of negative angle", "-90 sin", -1.0},
+		{"sin of large angle", "450 sin", 1.0},
+		{"cos 0", "0 cos", 1.0},
+		{"cos 90 is exact", "90 cos", 0.0},
+		{"cos 180", "180.0 cos", -1.0},
+		{"atan first quadrant", "1 1 atan", 45.0},
+		{"atan positive y axis", "1 0 atan", 90.0},
+		{"atan second quadrant", "1 -1 atan", 135.0},
+		{"atan negative x axis", "0 -1 atan", 180.0},
+		{"atan third quadrant", "-1 -1 atan", 225.0},
+		{"atan negative y axis", "-1 0 atan", 270.0},
+		{"atan fourth quadrant", "-1 1 atan", 315.0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestExpAndLogarithms(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected float64
+	}{
+		{"integer power", "2 10 exp", 1024.0},
+		{"square root power", "9 0.5 exp", 3.0},
+		{"negative base integer power", "-2 3 exp", -8.0},
+		{"negative exponent", "2 -1 exp", 0.5},
+		{"ln 1", "1 ln", 0.0},
+		{"ln e", "1 1 exp ln", 0.0},
+		{"log 100", "100 log", 2.0},
+		{"log of real", "0.001 log", -3.0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestMathErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"atan of 0 0", "0 0 atan", "undefinedresult"},
+		{"negative base fractional power", "-8 0.5 exp", "undefinedresult"},
+		{"zero to a negative power", "0 -1 exp", "undefinedresult"},
+		{"ln of 0", "0 ln", "rangecheck"},
+		{"log of negative", "-10 log", "rangecheck"},
+		{"sin of a string", "(a) sin", "typecheck"},
+		{"srand with a real", "1.5 srand", "typecheck"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := CreateInterpreter()
+			err := testInterpreter.Run(test.input)
+
+			psErr, ok := err.(*PSError)
+			if !ok || psErr.Name != test.expected {
+				t.Errorf("expected %s, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestRandom(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected PSConstant
+	}{
+		// the minimal standard generator gives 16807^n mod 2^31-1 from a seed of 1
+		{"first from seed 1", "1 srand rand", 16807},
+		{"second from seed 1", "1 srand rand pop rand", 282475249},
+		{"10000th from seed 1", "1 srand 10000 {rand pop} repeat rrand", 1043618065},
+		{"default seed is 1", "rand", 16807},
+		{"rrand is the last number", "1 srand rand pop rand pop rrand", 282475249},
+		{"srand restores rrand", "5 srand rand pop rrand rand exch srand rand eq", true},
+		{"zero seed", "0 srand rrand", 1},
+		{"negative seed", "-5 srand rrand", 6},
+		{"largest seed", "2147483647 srand rrand", 2147483646},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package ps
+
+// ======================================== array operations
+
+// helper to check whether an object is an array (literal or executable)
+func isArray(obj PSConstant) bool {
+	_, ok := obj.(PSArray)
+	return ok
+}
+
+// opArrayFromMark collects everything above the topmost mark into a new array
+func opArrayFromMark(i *Interpreter) error {
+	count, err := i.opStack.CountToMark()
+	if err != nil {
+		return newPSError("unmatchedmark", "] without a matching [")
+	}
+
+	items := make([]PSConstant, count)
+	for index := count - 1; index >= 0; index-- {
+		items[index], _ = i.opStack.Pop()
+	}
+	i.opStack.Pop() // the mark itself
+
+	i.opStack.Push(CreateArray(items))
+	return nil
+}
+
+// opArray creates an array of n null objects
+func opArray(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	size, ok := val.(int)
+	if !ok {
+		return typeCheck("array requires an integer size")
+	}
+	if size < 0 {
+		return rangeCheck("array size cannot be negative")
+	}
+
+	items := make([]PSConstant, size)
+	for index := range items {
+		items[index] = PSNull{}
+	}
+
+	i.opStack.Push(CreateArray(items))
+	return nil
+}
+
+// opAload pushes every element of an array followed by the array itself
+func opAload(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	arr, ok := val.(PSArray)
+	if !ok {
+		return typeCheck("aload requires an array")
+	}
+	if err := checkRead(arr, "aload"); err != nil {
+		return err
+	}
+
+	for _, item := range arr.Items {
+		i.opStack.Push(item)
+	}
+	i.opStack.Push(arr)
+	return nil
+}
+
+// opAstore fills an array with the n objects below it on the stack, n being the array length
+func opAstore(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Peek()
+
+	arr, ok := val.(PSArray)
+	if !ok {
+		return typeCheck("astore requires an array")
+	}
+	if err := checkWrite(arr, "astore"); err != nil {
+		return err
+	}
+	if i.opStack.StackCount() < len(arr.Items)+1 {
+		return stackUnderflow()
+	}
+
+	i.opStack.Pop()
+	for index := len(arr.Items) - 1; index >= 0; index-- {
+		arr.Items[index], _ = i.opStack.Pop()
+	}
+	i.opStack.Push(arr)
+	return nil
+}
+
+// opNull pushes the null object
+func opNull(i *Interpreter) error {
+	i.opStack.Push(PSNull{})
+	return nil
+}
+
+// opArrayGet pushes the element of an array at an index
+func opArrayGet(i *Interpreter) error {
+	indexVal, _ := i.opStack.Pop()
+	arrVal, _ := i.opStack.Pop()
+
+	index, ok := indexVal.(int)
+	if !ok {
+		return typeCheck("get requires an integer index")
+	}
+	arr := arrVal.(PSArray)
+	if err := checkRead(arr, "get"); err != nil {
+		return err
+	}
+
+	if index < 0 || index >= len(arr.Items) {
+		return rangeCheck("out of bounds index")
+	}
+
+	i.opStack.Push(arr.Items[index])
+	return nil
+}
+
+// opPut stores a value in an array at an index, or in a dictionary under a key
+// the array is changed in place so every reference to it sees the new value
+func opPut(i *Interpreter) error {
+	if i.opStack.StackCount() < 3 {
+		return stackUnderflow()
+	}
+
+	// dictionaries are handled in dictionary_ops.go, strings in string_ops.go
+	composite, _ := i.opStack.Index(2)
+	if isDict(composite) {
+		return dOpPut(i)
+	}
+	if isString(composite) {
+		return opStringPut(i)
+	}
+
+	value, _ := i.opStack.Pop()
+	indexVal, _ := i.opStack.Pop()
+	arrVal, _ := i.opStack.Pop()
+
+	arr, okArr := arrVal.(PSArray)
+	index, okIndex := indexVal.(int)
+	if !okArr || !okIndex {
+		return typeCheck("put requires an array and an integer index")
+	}
+	if err := checkWrite(arr, "put"); err != nil {
+		return err
+	}
+	if index < 0 || index >= len(arr.Items) {
+		return rangeCheck("out of bounds index")
+	}
+
+	arr.Items[index] = value
+	return nil
+}
+
+// opArrayGetInterval pushes a subarray sharing storage with the original array
+func opArrayGetInterval(i *Interpreter) error {
+	countVal, _ := i.opStack.Pop()
+	indexVal, _ := i.opStack.Pop()
+	arrVal, _ := i.opStack.Pop()
+
+	count, okCount := countVal.(int)
+	index, okIndex := indexVal.(int)
+	if !okCount || !okIndex {
+		return typeCheck("getinterval requires an integer index and count")
+	}
+	arr := arrVal.(PSArray)
+	if err := checkRead(arr, "getinterval"); err != nil {
+		return err
+	}
+
+	if index < 0 || count < 0 || index+count > len(arr.Items) {
+		return rangeCheck("interval goes beyond array length")
+	}
+
+	// slicing keeps the same backing storage, so the subarray aliases the original
+	i.opStack.Push(arr.subarray(index, index+count))
+	return nil
+}
+
+// opArrayPutInterval copies the elements of one array into another starting at an index
+func opArrayPutInterval(i *Interpreter) error {
+	srcVal, _ := i.opStack.Pop()
+	indexVal, _ := i.opStack.Pop()
+	destVal, _ := i.opStack.Pop()
+
+	src, okSrc := srcVal.(PSArray)
+	index, okIndex := indexVal.(int)
+	if !okSrc || !okIndex {
+		return typeCheck("putinterval requires two arrays and an integer index")
+	}
+	dest := destVal.(PSArray)
+	if err := checkWrite(dest, "putinterval"); err != nil {
+		return err
+	}
+	if err := checkRead(src, "putinterval"); err != nil {
+		return err
+	}
+
+	if index < 0 || index+len(src.Items) > len(dest.Items) {
+		return rangeCheck("interval goes beyond array length")
+	}
+
+	copy(dest.Items[index:], src.Items)
+	return nil
+}
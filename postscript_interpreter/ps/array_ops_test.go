@@ -0,0 +1,120 @@
+package ps
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestArrayConstruction(t *testing.T) {
+	// [1 2 3] should leave a single literal array on the stack
+	i := runTest(t, "[1 2 3]")
+	compareStackCount(t, i, 1)
+
+	top, _ := i.opStack.Peek()
+	arr, ok := top.(PSArray)
+	if !ok {
+		t.Fatalf("Expected PSArray on top of stack, got %T", top)
+	}
+	if arr.Executable {
+		t.Error("Expected [ ] to build a literal array")
+	}
+	if len(arr.Items) != 3 || arr.Items[0] != 1 || arr.Items[2] != 3 {
+		t.Errorf("Expected [1 2 3], got %v", arr.Items)
+	}
+}
+
+func TestArrayConstructionEvaluates(t *testing.T) {
+	// contents of [ ] are executed, so operators run while building
+	i := runTest(t, "[1 2 add 4] 0 get")
+	compareStackTop(t, i, 3)
+}
+
+func TestArrayUnmatchedMark(t *testing.T) {
+	i := CreateInterpreter()
+	err := i.Run("1 2 ]")
+
+	var psErr *PSError
+	if !errors.As(err, &psErr) || psErr.Name != "unmatchedmark" {
+		t.Errorf("Expected unmatchedmark, got %v", err)
+	}
+}
+
+func TestOpArray(t *testing.T) {
+	i := runTest(t, "3 array")
+
+	top, _ := i.opStack.Peek()
+	arr := top.(PSArray)
+	if len(arr.Items) != 3 {
+		t.Fatalf("Expected array of length 3, got %d", len(arr.Items))
+	}
+	for _, item := range arr.Items {
+		if item != (PSNull{}) {
+			t.Errorf("Expected null elements, got %v", item)
+		}
+	}
+}
+
+func TestOpArrayLength(t *testing.T) {
+	i := runTest(t, "[1 2 3 4] length")
+	compareStackTop(t, i, 4)
+}
+
+func TestOpArrayGet(t *testing.T) {
+	i := runTest(t, "[5 6 7] 1 get")
+	compareStackTop(t, i, 6)
+}
+
+func TestOpArrayGetOutOfRange(t *testing.T) {
+	i := CreateInterpreter()
+	if err := i.Run("[5 6 7] 3 get"); err == nil {
+		t.Error("Expected rangecheck error")
+	}
+}
+
+func TestOpPutShared(t *testing.T) {
+	// put changes the array in place, so the defined name sees the change
+	i := runTest(t, "/a [1 2 3] def a 0 99 put a 0 get")
+	compareStackTop(t, i, 99)
+}
+
+func TestOpArrayGetIntervalShares(t *testing.T) {
+	// changing the subarray changes the original
+	i := runTest(t, "/a [1 2 3 4] def a 1 2 getinterval 0 42 put a 1 get")
+	compareStackTop(t, i, 42)
+}
+
+func TestOpArrayPutInterval(t *testing.T) {
+	i := runTest(t, "/a [1 2 3 4] def a 2 [8 9] putinterval a 3 get")
+	compareStackTop(t, i, 9)
+}
+
+func TestOpAload(t *testing.T) {
+	i := runTest(t, "[1 2 3] aload pop")
+	compareStackCount(t, i, 3)
+	compareStackTop(t, i, 3)
+}
+
+func TestOpAstore(t *testing.T) {
+	i := runTest(t, "7 8 9 3 array astore 2 get")
+	compareStackCount(t, i, 1)
+	compareStackTop(t, i, 9)
+}
+
+func TestProcedureIsArray(t *testing.T) {
+	// procedures can be indexed and modified like arrays
+	i := runTest(t, "/p {1 2 add} def /p load 0 10 put p")
+	compareStackTop(t, i, 12)
+}
+
+func TestFormatArray(t *testing.T) {
+	i := runTest(t, "[1 (a) /b [2]] {1 x}")
+
+	proc, _ := i.opStack.Pop()
+	if formatObject(proc) != "{1 x}" {
+		t.Errorf("Expected {1 x}, got %s", formatObject(proc))
+	}
+	arr, _ := i.opStack.Pop()
+	if formatObject(arr) != "[1 (a) /b [2]]" {
+		t.Errorf("Expected [1 (a) /b [2]], got %s", formatObject(arr))
+	}
+}
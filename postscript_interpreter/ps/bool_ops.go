@@ -0,0 +1,107 @@
+package ps
+
+// ================================ boolean operations
+
+// opAnd performs logical AND on booleans, bitwise AND on integers
+func opAnd(i *Interpreter) error {
+	return logicalWith(i, "and",
+		func(x, y bool) bool { return x && y },
+		func(x, y int) int { return x & y })
+}
+
+// opOr performs logical OR on booleans, bitwise OR on integers
+func opOr(i *Interpreter) error {
+	return logicalWith(i, "or",
+		func(x, y bool) bool { return x || y },
+		func(x, y int) int { return x | y })
+}
+
+// opXor performs logical exclusive OR on booleans, bitwise exclusive OR on integers
+func opXor(i *Interpreter) error {
+	return logicalWith(i, "xor",
+		func(x, y bool) bool { return x != y },
+		func(x, y int) int { return x ^ y })
+}
+
+// shared body of and, or and xor, the operation used depends on whether the operands are booleans or integers
+func logicalWith(i *Interpreter, name string, boolFn func(bool, bool) bool, intFn func(int, int) int) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+	y, _ := i.opStack.Pop()
+	x, _ := i.opStack.Pop()
+
+	boolX, okBoolX := x.(bool)
+	boolY, okBoolY := y.(bool)
+	if okBoolX && okBoolY {
+		i.opStack.Push(boolFn(boolX, boolY))
+		return nil
+	}
+
+	// 32 bit operands give a 32 bit result, so it's always still an integer
+	intX, okIntX := x.(int)
+	intY, okIntY := y.(int)
+	if okIntX && okIntY {
+		i.opStack.Push(intFn(intX, intY))
+		return nil
+	}
+
+	return typeCheck("%s requires two booleans or two integers", name)
+}
+
+// opNot performs logical NOT on a boolean, bitwise complement on an integer
+func opNot(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	switch x := val.(type) {
+	case bool:
+		i.opStack.Push(!x)
+	case int:
+		i.opStack.Push(^x)
+	default:
+		return typeCheck("not requires a boolean or an integer")
+	}
+
+	return nil
+}
+
+// opBitshift shifts the bits of an integer left by shift places, or right if shift is negative
+// int shift bitshift → int, bits shifted out of the 32 bits are lost and zeros are shifted in
+func opBitshift(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+	s, _ := i.opStack.Pop()
+	x, _ := i.opStack.Pop()
+
+	num, okNum := x.(int)
+	shift, okShift := s.(int)
+	if !okNum || !okShift {
+		return typeCheck("bitshift requires two integers")
+	}
+
+	bits := uint32(num)
+	if shift >= 0 {
+		bits <<= min(shift, 32)
+	} else {
+		bits >>= min(-shift, 32)
+	}
+
+	i.opStack.Push(int(int32(bits)))
+	return nil
+}
+
+// opTrue pushes true onto stack
+func opTrue(i *Interpreter) error {
+	i.opStack.Push(true)
+	return nil
+}
+
+// opFalse pushes false onto stack
+func opFalse(i *Interpreter) error {
+	i.opStack.Push(false)
+	return nil
+}
@@ -1,4 +1,4 @@
-package main
+package ps
 
 import (
 	"testing"
@@ -114,7 +114,7 @@ func TestOpNot(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.input)
+			testInterpreter.opStack.Push(testValue(test.input))
 
 			err := opNot(testInterpreter)
 			if err != nil {
@@ -140,7 +140,7 @@ func TestOpNotStackUnderflow(t *testing.T) {
 
 func TestOpNotTypeError(t *testing.T) {
 	testInterpreter := CreateInterpreter()
-	testInterpreter.opStack.Push(5) // not a boolean val
+	testInterpreter.opStack.Push(2.5) // neither a boolean nor an integer
 
 	err := opNot(testInterpreter)
 	if err == nil {
@@ -165,8 +165,8 @@ func TestOpOr(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.a)
-			testInterpreter.opStack.Push(test.b)
+			testInterpreter.opStack.Push(testValue(test.a))
+			testInterpreter.opStack.Push(testValue(test.b))
 
 			err := opOr(testInterpreter)
 			if err != nil {
@@ -213,7 +213,7 @@ func TestOpOrTypeError(t *testing.T) {
 	}{
 		{"int and bool", 5, true},
 		{"bool and int", true, 5},
-		{"two ints", 5, 10},
+		{"two reals", 5.0, 10.0},
 		{"string and bool", "hello", true},
 		{"bool and string", false, "world"},
 	}
@@ -221,8 +221,8 @@ func TestOpOrTypeError(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.a)
-			testInterpreter.opStack.Push(test.b)
+			testInterpreter.opStack.Push(testValue(test.a))
+			testInterpreter.opStack.Push(testValue(test.b))
 
 			err := opOr(testInterpreter)
 			if err == nil {
@@ -307,3 +307,59 @@ func TestBooleanComplexExpression(t *testing.T) {
 		t.Errorf("expected empty stack, got %d items", testInterpreter.opStack.StackCount())
 	}
 }
+
+// bitwise operations ==========================================
+
+func TestBitwiseOperators(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected PSConstant
+	}{
+		{"and integers", "12 10 and", 8},
+		{"or integers", "12 10 or", 14},
+		{"xor integers", "12 10 xor", 6},
+		{"and negative", "-1 255 and", 255},
+		{"not integer", "0 not", -1},
+		{"not negative integer", "-6 not", 5},
+		{"xor true false", "true false xor", true},
+		{"xor true true", "true true xor", false},
+		{"shift left", "7 3 bitshift", 56},
+		{"shift right", "142 -3 bitshift", 17},
+		{"shift into sign bit", "1 31 bitshift", -2147483648},
+		{"shift right fills with zeros", "-8 -1 bitshift", 2147483644},
+		{"shift out every bit", "1 32 bitshift", 0},
+		{"shift by zero", "5 0 bitshift", 5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestBitwiseTypeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"and mixes boolean and integer", "true 1 and"},
+		{"xor reals", "1.0 2.0 xor"},
+		{"bitshift real", "1.5 2 bitshift"},
+		{"bitshift real shift", "1 2.0 bitshift"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := CreateInterpreter()
+			err := testInterpreter.Run(test.input)
+
+			psErr, ok := err.(*PSError)
+			if !ok || psErr.Name != "typecheck" {
+				t.Errorf("expected typecheck, got %v", err)
+			}
+		})
+	}
+}
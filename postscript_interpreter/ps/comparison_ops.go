@@ -0,0 +1,151 @@
+package ps
+
+import (
+	"bytes"
+	"cmp"
+)
+
+// ================================ comparison operations
+
+// reports whether two objects are equal by PostScript's rules, as used by eq and ne
+// numbers are compared by value whatever their type, strings and names by their text, so (a) and /a are equal,
+// and arrays, dictionaries and the other composite objects only if they're the same object
+func objectsEqual(x, y PSConstant, command string) (bool, error) {
+	numX, errX := convertToNumber(x)
+	numY, errY := convertToNumber(y)
+	if errX == nil && errY == nil {
+		return numX == numY, nil
+	}
+
+	textX, okX := textOf(x)
+	textY, okY := textOf(y)
+	if okX && okY {
+		if err := checkRead(x, command); err != nil {
+			return false, err
+		}
+		if err := checkRead(y, command); err != nil {
+			return false, err
+		}
+		return textX == textY, nil
+	}
+
+	// arrays are the same array when they're the same part of the same storage, like for dictionary keys
+	arrX, okX := x.(PSArray)
+	arrY, okY := y.(PSArray)
+	if okX || okY {
+		if !okX || !okY {
+			return false, nil
+		}
+		keyX, _ := dictKey(arrX)
+		keyY, _ := dictKey(arrY)
+		return keyX == keyY, nil
+	}
+
+	// what's left is comparable in Go: booleans, null and mark by value, dictionaries, operators and files by identity
+	return x == y, nil
+}
+
+// the text of a string or name, ok is false for other objects
+func textOf(obj PSConstant) (string, bool) {
+	switch val := obj.(type) {
+	case PSString:
+		return val.String(), true
+	case PSName:
+		return string(val), true
+	case PSExecName:
+		return string(val), true
+	default:
+		return "", false
+	}
+}
+
+// opEq pushes true if two objects are equal
+func opEq(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	y, _ := i.opStack.Pop()
+	x, _ := i.opStack.Pop()
+
+	equal, err := objectsEqual(x, y, "eq")
+	if err != nil {
+		return err
+	}
+	i.opStack.Push(equal)
+	return nil
+}
+
+// opNe pushes true if two objects are not equal
+func opNe(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	y, _ := i.opStack.Pop()
+	x, _ := i.opStack.Pop()
+
+	equal, err := objectsEqual(x, y, "ne")
+	if err != nil {
+		return err
+	}
+	i.opStack.Push(!equal)
+	return nil
+}
+
+// opGe pushes true if one item is greater than or equal to the other
+func opGe(i *Interpreter) error {
+	return orderWith(i, "ge", func(order int) bool { return order >= 0 })
+}
+
+// opGt pushes true if one item is greater than the other
+func opGt(i *Interpreter) error {
+	return orderWith(i, "gt", func(order int) bool { return order > 0 })
+}
+
+// opLe pushes true if one item is less than or equal to the other
+func opLe(i *Interpreter) error {
+	return orderWith(i, "le", func(order int) bool { return order <= 0 })
+}
+
+// opLt pushes true if one item is less than the other
+func opLt(i *Interpreter) error {
+	return orderWith(i, "lt", func(order int) bool { return order < 0 })
+}
+
+// shared body of the ordering operators, test is given -1, 0 or 1 as the first operand is less than,
+// equal to or greater than the second
+// numbers are ordered by value and strings byte by byte, a string that's a prefix of another coming first
+func orderWith(i *Interpreter, name string, test func(int) bool) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	y, _ := i.opStack.Pop()
+	x, _ := i.opStack.Pop()
+
+	// trying as numbers first
+	numX, errX := convertToNumber(x)
+	numY, errY := convertToNumber(y)
+	if errX == nil && errY == nil {
+		i.opStack.Push(test(cmp.Compare(numX, numY)))
+		return nil
+	}
+
+	// trying as strings
+	strX, okX := x.(PSString)
+	strY, okY := y.(PSString)
+	if okX && okY {
+		if err := checkRead(strX, name); err != nil {
+			return err
+		}
+		if err := checkRead(strY, name); err != nil {
+			return err
+		}
+		i.opStack.Push(test(bytes.Compare(strX.Bytes, strY.Bytes)))
+		return nil
+	}
+
+	// accounting for type mismatch
+	return typeCheck("%s requires two numbers or two strings", name)
+}
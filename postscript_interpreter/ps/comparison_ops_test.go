@@ -1,4 +1,4 @@
-package main
+package ps
 
 import (
 	"testing"
@@ -39,8 +39,8 @@ func TestOpEq(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.a)
-			testInterpreter.opStack.Push(test.b)
+			testInterpreter.opStack.Push(testValue(test.a))
+			testInterpreter.opStack.Push(testValue(test.b))
 
 			err := opEq(testInterpreter)
 			if err != nil {
@@ -90,8 +90,8 @@ func TestOpNe(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.a)
-			testInterpreter.opStack.Push(test.b)
+			testInterpreter.opStack.Push(testValue(test.a))
+			testInterpreter.opStack.Push(testValue(test.b))
 
 			err := opNe(testInterpreter)
 			if err != nil {
@@ -144,8 +144,8 @@ func TestOpLt(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.a)
-			testInterpreter.opStack.Push(test.b)
+			testInterpreter.opStack.Push(testValue(test.a))
+			testInterpreter.opStack.Push(testValue(test.b))
 
 			err := opLt(testInterpreter)
 			if err != nil {
@@ -175,8 +175,8 @@ func TestOpLtStrings(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.a)
-			testInterpreter.opStack.Push(test.b)
+			testInterpreter.opStack.Push(testValue(test.a))
+			testInterpreter.opStack.Push(testValue(test.b))
 
 			err := opLt(testInterpreter)
 			if err != nil {
@@ -228,8 +228,8 @@ func TestOpGt(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.a)
-			testInterpreter.opStack.Push(test.b)
+			testInterpreter.opStack.Push(testValue(test.a))
+			testInterpreter.opStack.Push(testValue(test.b))
 
 			err := opGt(testInterpreter)
 			if err != nil {
@@ -277,8 +277,8 @@ func TestOpGe(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.a)
-			testInterpreter.opStack.Push(test.b)
+			testInterpreter.opStack.Push(testValue(test.a))
+			testInterpreter.opStack.Push(testValue(test.b))
 
 			err := opGe(testInterpreter)
 			if err != nil {
@@ -369,7 +369,7 @@ func TestComparisonTypeErrors(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
 			testInterpreter.opStack.Push(5)
-			testInterpreter.opStack.Push("hello")
+			testInterpreter.opStack.Push(CreateString("hello"))
 
 			err := test.op(testInterpreter)
 			if err == nil {
@@ -378,3 +378,112 @@ func TestComparisonTypeErrors(t *testing.T) {
 		})
 	}
 }
+
+// equality across object types ==================================
+
+func TestEqObjectTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"equal names", "/a /a eq", true},
+		{"unequal names", "/a /b eq", false},
+		{"literal and executable name", "/a /a cvx eq", true},
+		{"name equals string of same text", "/abc (abc) eq", true},
+		{"string equals name of same text", "(abc) /abc eq", true},
+		{"name and different string", "/abc (abd) eq", false},
+		{"equal booleans", "true true eq", true},
+		{"unequal booleans", "true false eq", false},
+		{"boolean and integer", "true 1 eq", false},
+		{"nulls", "null null eq", true},
+		{"marks", "mark mark eq", true},
+		{"null and mark", "null mark eq", false},
+		{"same operator", "/add load /add load eq", true},
+		{"different operators", "/add load /sub load eq", false},
+		{"same array", "/a [1 2] def a a eq", true},
+		{"arrays with equal contents", "[1 2] [1 2] eq", false},
+		{"empty arrays", "[] [] eq", false},
+		{"new empty arrays", "0 array 0 array eq", false},
+		{"same empty array", "/a 0 array def a a eq", true},
+		{"empty procedures", "{} {} eq", false},
+		{"empty subarrays at different places", "/a [1 2] def a 0 0 getinterval a 1 0 getinterval eq", false},
+		{"subarray of same storage", "/a [1 2] def a a 0 2 getinterval eq", true},
+		{"shorter subarray", "/a [1 2] def a a 0 1 getinterval eq", false},
+		{"same procedure", "/p {1} def /p load /p load eq", true},
+		{"same dict", "/d 1 dict def d d eq", true},
+		{"different dicts", "1 dict 1 dict eq", false},
+		{"systemdict", "systemdict systemdict eq", true},
+		{"string and integer", "(1) 1 eq", false},
+		{"array and dict", "[] 0 dict eq", false},
+		{"ne names", "/a /b ne", true},
+		{"ne same dict", "userdict userdict ne", false},
+		{"ne name and string", "/x (x) ne", false},
+		{"ne mixed types", "(1) 1 ne", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+// ordering of strings ==========================================
+
+func TestStringOrdering(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"lt by first byte", "(abc) (abd) lt", true},
+		{"prefix comes first", "(ab) (abc) lt", true},
+		{"longer is greater", "(abc) (ab) gt", true},
+		{"empty string first", "() (a) lt", true},
+		{"uppercase before lowercase", "(Z) (a) lt", true},
+		{"compared as unsigned bytes", "<ff> (a) gt", true},
+		{"equal strings ge", "(abc) (abc) ge", true},
+		{"equal strings le", "(abc) (abc) le", true},
+		{"equal strings not lt", "(abc) (abc) lt", false},
+		{"equal strings not gt", "(abc) (abc) gt", false},
+		{"ge greater", "(b) (a) ge", true},
+		{"le greater", "(b) (a) le", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestComparisonErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"lt string and integer", "(a) 1 lt", "typecheck"},
+		{"gt names", "/a /b gt", "typecheck"},
+		{"le booleans", "true false le", "typecheck"},
+		{"ge arrays", "[1] [2] ge", "typecheck"},
+		{"eq unreadable string", "(a) noaccess (a) eq", "invalidaccess"},
+		{"ne unreadable string", "(a) (a) executeonly ne", "invalidaccess"},
+		{"lt unreadable string", "(a) noaccess (b) lt", "invalidaccess"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := CreateInterpreter()
+			err := testInterpreter.Run(test.input)
+
+			psErr, ok := err.(*PSError)
+			if !ok || psErr.Name != test.expected {
+				t.Errorf("expected %s, got %v", test.expected, err)
+			}
+		})
+	}
+}
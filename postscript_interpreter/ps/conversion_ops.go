@@ -0,0 +1,286 @@
+package ps
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ======================================== conversion operators
+
+// opCvx makes the top of the stack executable
+func opCvx(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	switch obj := val.(type) {
+	case PSArray:
+		obj.Executable = true
+		i.opStack.Push(obj)
+	case PSString:
+		obj.Executable = true
+		i.opStack.Push(obj)
+	case PSName:
+		i.opStack.Push(PSExecName(obj))
+	default:
+		// other objects have no executable form, they are left as they are
+		i.opStack.Push(obj)
+	}
+
+	return nil
+}
+
+// opCvlit makes the top of the stack literal
+func opCvlit(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	switch obj := val.(type) {
+	case PSArray:
+		obj.Executable = false
+		i.opStack.Push(obj)
+	case PSString:
+		obj.Executable = false
+		i.opStack.Push(obj)
+	case PSExecName:
+		i.opStack.Push(PSName(obj))
+	default:
+		i.opStack.Push(obj)
+	}
+
+	return nil
+}
+
+// opXcheck pushes true if the top of the stack is executable
+func opXcheck(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	switch obj := val.(type) {
+	case PSArray:
+		i.opStack.Push(obj.Executable)
+	case PSString:
+		i.opStack.Push(obj.Executable)
+	case PSExecName, *PSOperator:
+		i.opStack.Push(true)
+	default:
+		i.opStack.Push(false)
+	}
+
+	return nil
+}
+
+// parses a string the way the tokenizer reads a number, used by cvi and cvr
+func parseNumberString(str string) (float64, bool, error) {
+	tokens, err := CreateTokenizer(str).Tokenize()
+	if err != nil || len(tokens) != 1 {
+		return 0, false, newPSError("syntaxerror", "(%s) is not a number", str)
+	}
+
+	switch val := tokens[0].Value.(type) {
+	case int:
+		return float64(val), true, nil
+	case float64:
+		return val, false, nil
+	default:
+		return 0, false, typeCheck("(%s) is not a number", str)
+	}
+}
+
+// opCvi converts a number or numeric string to an integer, truncating towards zero
+func opCvi(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	var num float64
+	switch obj := val.(type) {
+	case int:
+		i.opStack.Push(obj)
+		return nil
+	case float64:
+		num = obj
+	case PSString:
+		parsed, _, err := parseNumberString(obj.String())
+		if err != nil {
+			return err
+		}
+		num = parsed
+	default:
+		return typeCheck("cvi requires a number or string")
+	}
+
+	truncated := math.Trunc(num)
+	if math.IsNaN(truncated) || truncated > maxPSInt || truncated < minPSInt {
+		return rangeCheck("%v does not fit in an integer", num)
+	}
+
+	i.opStack.Push(int(truncated))
+	return nil
+}
+
+// opCvr converts a number or numeric string to a real
+func opCvr(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	switch obj := val.(type) {
+	case int:
+		i.opStack.Push(float64(obj))
+	case float64:
+		i.opStack.Push(obj)
+	case PSString:
+		parsed, _, err := parseNumberString(obj.String())
+		if err != nil {
+			return err
+		}
+		i.opStack.Push(parsed)
+	default:
+		return typeCheck("cvr requires a number or string")
+	}
+
+	return nil
+}
+
+// opType pushes the name of the type of any object, e.g. integertype or dicttype
+// the name is executable, so it can be looked up in a dictionary of procedures to dispatch on type
+func opType(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	i.opStack.Push(PSExecName(typeName(val)))
+	return nil
+}
+
+// the standard PostScript name for the type of obj
+func typeName(obj PSConstant) string {
+	switch obj.(type) {
+	case int:
+		return "integertype"
+	case float64:
+		return "realtype"
+	case bool:
+		return "booleantype"
+	case PSName, PSExecName:
+		return "nametype"
+	case PSString:
+		return "stringtype"
+	case PSArray:
+		return "arraytype"
+	case *PSDict:
+		return "dicttype"
+	case *PSOperator:
+		return "operatortype"
+	case *PSFile:
+		return "filetype"
+	case PSMark:
+		return "marktype"
+	default:
+		return "nulltype"
+	}
+}
+
+// opCvn converts a string to a name, executable if the string was
+func opCvn(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	str, ok := val.(PSString)
+	if !ok {
+		return typeCheck("cvn requires a string")
+	}
+	if err := checkRead(str, "cvn"); err != nil {
+		return err
+	}
+
+	if str.Executable {
+		i.opStack.Push(PSExecName(str.String()))
+	} else {
+		i.opStack.Push(PSName(str.String()))
+	}
+	return nil
+}
+
+// opCvs writes the text = would print for any object into a string
+// any string cvs → substring, the part of the string that was written
+func opCvs(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+	strVal, _ := i.opStack.Pop()
+	val, _ := i.opStack.Pop()
+
+	str, ok := strVal.(PSString)
+	if !ok {
+		return typeCheck("cvs requires a string to write into")
+	}
+	// the text of other composite objects is --nostringval--, only a string's contents are read
+	if isString(val) {
+		if err := checkRead(val, "cvs"); err != nil {
+			return err
+		}
+	}
+
+	return writeString(i, str, formatText(val), "cvs")
+}
+
+// opCvrs writes a number in the given radix into a string, digits above 9 being capital letters
+// num radix string cvrs → substring
+// in radix 10 it's written like cvs, in any other radix it's converted to an integer first and
+// negative integers are written as their 32 bit two's complement
+func opCvrs(i *Interpreter) error {
+	if i.opStack.StackCount() < 3 {
+		return stackUnderflow()
+	}
+	strVal, _ := i.opStack.Pop()
+	radixVal, _ := i.opStack.Pop()
+	val, _ := i.opStack.Pop()
+
+	str, okStr := strVal.(PSString)
+	radix, okRadix := radixVal.(int)
+	num, errNum := convertToNumber(val)
+	if !okStr || !okRadix || errNum != nil {
+		return typeCheck("cvrs requires a number, an integer radix and a string")
+	}
+	if radix < 2 || radix > 36 {
+		return rangeCheck("radix %d is not between 2 and 36", radix)
+	}
+
+	if radix == 10 {
+		return writeString(i, str, formatText(val), "cvrs")
+	}
+
+	truncated := math.Trunc(num)
+	if math.IsNaN(truncated) || truncated > maxPSInt || truncated < minPSInt {
+		return rangeCheck("%v does not fit in an integer", num)
+	}
+	text := strings.ToUpper(strconv.FormatUint(uint64(uint32(int32(truncated))), radix))
+	return writeString(i, str, text, "cvrs")
+}
+
+// copies text into the start of str and pushes the part that was written, rangecheck if it doesn't fit
+func writeString(i *Interpreter, str PSString, text string, command string) error {
+	if err := checkWrite(str, command); err != nil {
+		return err
+	}
+	if len(text) > len(str.Bytes) {
+		return rangeCheck("string of length %d can't hold %d characters", len(str.Bytes), len(text))
+	}
+
+	copy(str.Bytes, text)
+	i.opStack.Push(str.substring(0, len(text)))
+	return nil
+}
@@ -0,0 +1,289 @@
+package ps
+
+import (
+	"testing"
+)
+
+func TestOpCvxName(t *testing.T) {
+	// cvx on a literal name gives an executable name
+	i := CreateInterpreter()
+	i.opStack.Push(PSName("x"))
+
+	err := opCvx(i)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compareStackTop(t, i, PSExecName("x"))
+}
+
+func TestOpCvlitName(t *testing.T) {
+	i := CreateInterpreter()
+	i.opStack.Push(PSExecName("x"))
+
+	err := opCvlit(i)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compareStackTop(t, i, PSName("x"))
+}
+
+func TestOpCvlitProcedure(t *testing.T) {
+	// {1} cvlit xcheck -> false
+	tokens := []Token{
+		{Type: TOKEN_BLOCK_START},
+		{Type: TOKEN_INT, Value: 1},
+		{Type: TOKEN_BLOCK_END},
+		{Type: TOKEN_OPERATOR, Value: "cvlit"},
+		{Type: TOKEN_OPERATOR, Value: "xcheck"},
+	}
+	testInterpreter := executeTest(t, tokens)
+	compareStackTop(t, testInterpreter, false)
+}
+
+func TestOpXcheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    PSConstant
+		expected bool
+	}{
+		{"integer", 5, false},
+		{"string", "hello", false},
+		{"literal name", PSName("x"), false},
+		{"executable name", PSExecName("x"), true},
+		{"procedure", PSArray{Executable: true}, true},
+		{"literal array", PSArray{}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := CreateInterpreter()
+			i.opStack.Push(testValue(test.value))
+
+			err := opXcheck(i)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			compareStackTop(t, i, test.expected)
+		})
+	}
+}
+
+func TestOpCvxUnderflow(t *testing.T) {
+	i := CreateInterpreter()
+	if err := opCvx(i); err == nil {
+		t.Error("expected stack underflow error")
+	}
+}
+
+func TestOpCvi(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{"integer unchanged", "5 cvi", 5},
+		{"positive real truncates", "3.7 cvi", 3},
+		{"negative real truncates towards zero", "-3.7 cvi", -3},
+		{"integer string", "(42) cvi", 42},
+		{"real string", "(2.9) cvi", 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := runTest(t, test.input)
+			compareStackTop(t, i, test.expected)
+		})
+	}
+}
+
+func TestOpCviOutOfRange(t *testing.T) {
+	i := CreateInterpreter()
+	err := i.Run("3000000000.0 cvi")
+
+	psErr, ok := err.(*PSError)
+	if !ok || psErr.Name != "rangecheck" {
+		t.Errorf("expected rangecheck, got %v", err)
+	}
+}
+
+func TestOpCvr(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected float64
+	}{
+		{"integer", "5 cvr", 5.0},
+		{"real unchanged", "2.5 cvr", 2.5},
+		{"numeric string", "(7) cvr", 7.0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := runTest(t, test.input)
+			compareStackTop(t, i, test.expected)
+		})
+	}
+}
+
+func TestOpCvrBadString(t *testing.T) {
+	i := CreateInterpreter()
+	if err := i.Run("(abc) cvr"); err == nil {
+		t.Error("expected error converting a non-numeric string")
+	}
+}
+
+func TestOpType(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected PSConstant
+	}{
+		{"integer", "1 type", PSExecName("integertype")},
+		{"real", "1.5 type", PSExecName("realtype")},
+		{"boolean", "true type", PSExecName("booleantype")},
+		{"literal name", "/a type", PSExecName("nametype")},
+		{"string", "(x) type", PSExecName("stringtype")},
+		{"array", "[1] type", PSExecName("arraytype")},
+		{"procedure", "{1} type", PSExecName("arraytype")},
+		{"dictionary", "1 dict type", PSExecName("dicttype")},
+		{"operator", "/add load type", PSExecName("operatortype")},
+		{"file", "currentfile type", PSExecName("filetype")},
+		{"mark", "mark type", PSExecName("marktype")},
+		{"null", "null type", PSExecName("nulltype")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := runTest(t, test.input)
+			compareStackTop(t, i, test.expected)
+		})
+	}
+}
+
+func TestOpTypeDispatch(t *testing.T) {
+	i := runTest(t, `<< /integertype {(int)} /stringtype {(str)} >> begin 5 type exec (a) type exec end`)
+	compareStackTop(t, i, "str")
+	i.opStack.Pop()
+	compareStackTop(t, i, "int")
+}
+
+func TestOpCvn(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected PSConstant
+	}{
+		{"literal string", "(abc) cvn", PSName("abc")},
+		{"executable string", "(abc) cvx cvn", PSExecName("abc")},
+		{"looked up as a name", "/abc 5 def (abc) cvn load", 5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := runTest(t, test.input)
+			compareStackTop(t, i, test.expected)
+		})
+	}
+}
+
+func TestOpCvs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"integer", "42 10 string cvs", "42"},
+		{"negative integer", "-7 10 string cvs", "-7"},
+		{"real", "2.5 10 string cvs", "2.5"},
+		{"integral real", "3.0 10 string cvs", "3.0"},
+		{"name", "/abc 10 string cvs", "abc"},
+		{"string", "(hi) 10 string cvs", "hi"},
+		{"boolean", "true 10 string cvs", "true"},
+		{"operator", "/add load 10 string cvs", "--add--"},
+		{"array", "[1 2] 20 string cvs", "--nostringval--"},
+		{"exact fit", "123 3 string cvs", "123"},
+		{"writes into the string", "/s (xxxxx) def 12 s cvs pop s", "12xxx"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := runTest(t, test.input)
+			compareStackTop(t, i, test.expected)
+		})
+	}
+}
+
+func TestOpCvrs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"hexadecimal", "255 16 10 string cvrs", "FF"},
+		{"binary", "10 2 10 string cvrs", "1010"},
+		{"base 36", "35 36 10 string cvrs", "Z"},
+		{"negative is two's complement", "-1 16 10 string cvrs", "FFFFFFFF"},
+		{"real is truncated", "10.7 2 10 string cvrs", "1010"},
+		{"radix 10 real", "2.5 10 10 string cvrs", "2.5"},
+		{"radix 10 negative", "-12 10 10 string cvrs", "-12"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := runTest(t, test.input)
+			compareStackTop(t, i, test.expected)
+		})
+	}
+}
+
+func TestConversionErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"cvs string too short", "12345 3 string cvs", "rangecheck"},
+		{"cvs without a string", "1 2 cvs", "typecheck"},
+		{"cvrs string too short", "255 2 3 string cvrs", "rangecheck"},
+		{"cvrs radix too small", "5 1 10 string cvrs", "rangecheck"},
+		{"cvrs radix too large", "5 37 10 string cvrs", "rangecheck"},
+		{"cvrs real radix", "5 2.0 10 string cvrs", "typecheck"},
+		{"cvrs real too large", "1e20 16 30 string cvrs", "rangecheck"},
+		{"cvn of a name", "/a cvn", "typecheck"},
+		{"type of nothing", "type", "stackunderflow"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := CreateInterpreter()
+			err := i.Run(test.input)
+
+			psErr, ok := err.(*PSError)
+			if !ok || psErr.Name != test.expected {
+				t.Errorf("expected %s, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestExecutableString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected PSConstant
+	}{
+		{"exec runs it", "(1 2 add) cvx exec", 3},
+		{"xcheck", "(abc) cvx xcheck", true},
+		{"literal by default", "(abc) xcheck", false},
+		{"cvlit makes it literal again", "(1 2 add) cvx cvlit exec", "1 2 add"},
+		{"runs when named", "/s (3 4 mul) cvx def s", 12},
+		{"defines names", "(/z 9 def) cvx exec z", 9},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := runTest(t, test.input)
+			compareStackTop(t, i, test.expected)
+		})
+	}
+}
@@ -0,0 +1,502 @@
+package ps
+
+import (
+	"math"
+	"sort"
+)
+
+// ================================== Dictionary operations
+
+// reports whether obj is a dictionary
+func isDict(obj PSConstant) bool {
+	_, ok := obj.(*PSDict)
+	return ok
+}
+
+// a string used as a key, kept apart from the name with the same text
+type stringKey string
+
+// an array used as a key, arrays are the same key only if they're the same array:
+// the same part of the same storage
+type arrayKey struct {
+	storage *arrayStorage
+	first   *PSConstant // the first item instead, for arrays without a storage made outside CreateArray
+	start   int
+	length  int
+}
+
+// converts any object on the stack into the key it's stored under in a dictionary
+// keys are the same when eq says they are: names by their text, strings by their contents,
+// and reals with an integer value are the same key as the integer
+// the exception is a name and a string with the same text, which eq finds equal but are kept as separate keys
+func dictKey(k PSConstant) (PSConstant, error) {
+	switch val := k.(type) {
+	case PSName:
+		return string(val), nil
+	case PSExecName:
+		return string(val), nil
+	case PSString:
+		return stringKey(val.String()), nil
+	case float64:
+		if val == math.Trunc(val) && val >= math.MinInt32 && val <= math.MaxInt32 {
+			return int(val), nil
+		}
+		return val, nil
+	case PSArray:
+		key := arrayKey{storage: val.storage, start: val.start, length: len(val.Items)}
+		if val.storage == nil && len(val.Items) > 0 {
+			key.first = &val.Items[0]
+		}
+		return key, nil
+	case PSNull:
+		return nil, typeCheck("null can't be used as a key")
+	default:
+		return val, nil
+	}
+}
+
+// the object a key stored in the dictionary stands for, undoing dictKey
+func (d *PSDict) keyObject(key PSConstant) PSConstant {
+	switch val := key.(type) {
+	case string:
+		return PSName(val)
+	case stringKey:
+		return CreateString(string(val))
+	case arrayKey:
+		return d.arrayKeys[val]
+	default:
+		return key
+	}
+}
+
+// looks up the value stored under k
+func (d *PSDict) get(k PSConstant) (PSConstant, bool, error) {
+	key, err := dictKey(k)
+	if err != nil {
+		return nil, false, err
+	}
+	value, ok := d.items[key]
+	return value, ok, nil
+}
+
+// stores value under k, replacing any value already there
+func (d *PSDict) put(k PSConstant, value PSConstant) error {
+	key, err := dictKey(k)
+	if err != nil {
+		return err
+	}
+	if arrKey, ok := key.(arrayKey); ok {
+		if d.arrayKeys == nil {
+			d.arrayKeys = make(map[arrayKey]PSArray)
+		}
+		d.arrayKeys[arrKey] = k.(PSArray)
+	}
+	// keys without a value to sort by are kept in the order they were first stored
+	if _, exists := d.items[key]; !exists && keyRank(key) >= unorderedRank {
+		if d.sequence == nil {
+			d.sequence = make(map[PSConstant]int)
+		}
+		d.stored++
+		d.sequence[key] = d.stored
+	}
+	d.items[key] = value
+	return nil
+}
+
+// removes k and its value, keys that aren't there are ignored
+func (d *PSDict) remove(k PSConstant) error {
+	key, err := dictKey(k)
+	if err != nil {
+		return err
+	}
+	if arrKey, ok := key.(arrayKey); ok {
+		delete(d.arrayKeys, arrKey)
+	}
+	delete(d.sequence, key)
+	delete(d.items, key)
+	return nil
+}
+
+// the stored keys in a fixed order so forall and == give the same result every time
+// names come first, then strings, numbers and booleans, each sorted by value,
+// then arrays, dictionaries, operators, files and anything else, each in the order they were first stored
+func (d *PSDict) sortedKeys() []PSConstant {
+	keys := make([]PSConstant, 0, len(d.items))
+	for key := range d.items {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		rankA, rankB := keyRank(keys[a]), keyRank(keys[b])
+		if rankA != rankB {
+			return rankA < rankB
+		}
+		switch valA := keys[a].(type) {
+		case string:
+			return valA < keys[b].(string)
+		case stringKey:
+			return valA < keys[b].(stringKey)
+		case int, float64:
+			numA, _ := convertToNumber(valA)
+			numB, _ := convertToNumber(keys[b])
+			return numA < numB
+		case bool:
+			return !valA && keys[b].(bool)
+		default:
+			return d.sequence[valA] < d.sequence[keys[b]]
+		}
+	})
+	return keys
+}
+
+// the first rank in sortedKeys of keys that are ordered by when they were stored rather than by value
+const unorderedRank = 4
+
+// position of a kind of key in sortedKeys
+func keyRank(key PSConstant) int {
+	switch key.(type) {
+	case string:
+		return 0
+	case stringKey:
+		return 1
+	case int, float64:
+		return 2
+	case bool:
+		return 3
+	case arrayKey:
+		return unorderedRank
+	case *PSDict:
+		return unorderedRank + 1
+	case *PSOperator:
+		return unorderedRank + 2
+	case *PSFile:
+		return unorderedRank + 3
+	default:
+		return unorderedRank + 4
+	}
+}
+
+// dOpDict creates a PSDict  with given capacity and pushes it onto the opStack
+func dOpDict(i *Interpreter) error {
+
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+
+	capacity, _ := i.opStack.Pop()
+	cap, ok := capacity.(int)
+	if !ok {
+		return typeCheck("dict requires an integer capacity")
+	}
+	if cap < 0 {
+		return rangeCheck("dict capacity cannot be negative")
+	}
+
+	dictionary := &PSDict{
+		items:    make(map[PSConstant]PSConstant),
+		capacity: cap,
+	}
+
+	i.opStack.Push(dictionary)
+
+	return nil
+}
+
+// dOpBegin defines the start point of a new dictionary on the DictStack
+func dOpBegin(i *Interpreter) error {
+
+	val, err := i.opStack.Pop()
+	if err != nil {
+		return stackUnderflow()
+	}
+
+	// conversion check
+	dict, ok := val.(*PSDict)
+	if !ok {
+		return typeCheck("begin requires a dictionary")
+	}
+	if err := checkRead(dict, "begin"); err != nil {
+		return err
+	}
+
+	i.dictStack = append(i.dictStack, dict)
+	return nil
+}
+
+// dOpEnd defines the end point of a new dictionary on the DictStack
+func dOpEnd(i *Interpreter) error {
+
+	// systemdict and userdict can't be popped
+	if len(i.dictStack) <= 2 {
+		return newPSError("dictstackunderflow", "cannot end systemdict or userdict")
+	}
+
+	i.dictStack = i.dictStack[:len(i.dictStack)-1]
+	return nil
+}
+
+// dOpDef associates a key value pair for the current dictionary
+func dOpDef(i *Interpreter) error {
+
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	value, _ := i.opStack.Pop()
+	k, _ := i.opStack.Pop()
+
+	currentDict := i.dictStack[len(i.dictStack)-1]
+	if err := checkWrite(currentDict, "def"); err != nil {
+		return err
+	}
+	return currentDict.put(k, value)
+}
+
+// dOpMaxLength pushes the capacity of the current dictionary onto the stack
+func dOpMaxLength(i *Interpreter) error {
+
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+
+	val, _ := i.opStack.Pop()
+	currentDict, ok := val.(*PSDict)
+	if !ok {
+		return typeCheck("maxlength requires a dictionary")
+	}
+	i.opStack.Push(currentDict.capacity)
+	return nil
+}
+
+// dOpLoad looks up a key on the dict stack and pushes its value without executing it
+func dOpLoad(i *Interpreter) error {
+
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+
+	k, _ := i.opStack.Pop()
+
+	key, err := dictKey(k)
+	if err != nil {
+		return err
+	}
+
+	dict := i.dictWhere(key)
+	if dict == nil {
+		return newPSError("undefined", "%s is not defined in dictionary stack", formatText(k))
+	}
+
+	i.opStack.Push(dict.items[key])
+	return nil
+}
+
+// dOpDictFromMark builds a dictionary from the key value pairs above the topmost mark
+func dOpDictFromMark(i *Interpreter) error {
+	count, err := i.opStack.CountToMark()
+	if err != nil {
+		return newPSError("unmatchedmark", ">> without a matching <<")
+	}
+	if count%2 != 0 {
+		return rangeCheck("<< >> requires key value pairs")
+	}
+
+	dictionary := &PSDict{
+		items:    make(map[PSConstant]PSConstant),
+		capacity: count / 2,
+	}
+
+	// pairs come off the stack value first, keys are validated before anything is stored
+	pairs := make([]PSConstant, count)
+	for index := count - 1; index >= 0; index-- {
+		pairs[index], _ = i.opStack.Pop()
+	}
+	for index := 0; index < count; index += 2 {
+		if _, err := dictKey(pairs[index]); err != nil {
+			return err
+		}
+	}
+	for index := 0; index < count; index += 2 {
+		dictionary.put(pairs[index], pairs[index+1])
+	}
+	i.opStack.Pop() // the mark itself
+
+	i.opStack.Push(dictionary)
+	return nil
+}
+
+// dOpGet pushes the value stored under a key in a dictionary
+func dOpGet(i *Interpreter) error {
+
+	k, _ := i.opStack.Pop()
+	val, _ := i.opStack.Pop()
+	dict := val.(*PSDict)
+	if err := checkRead(dict, "get"); err != nil {
+		return err
+	}
+
+	value, ok, err := dict.get(k)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return newPSError("undefined", "%s is not defined in dictionary", formatText(k))
+	}
+	i.opStack.Push(value)
+	return nil
+}
+
+// dOpPut stores a value under a key in a dictionary, replacing any value already there
+func dOpPut(i *Interpreter) error {
+
+	value, _ := i.opStack.Pop()
+	k, _ := i.opStack.Pop()
+	val, _ := i.opStack.Pop()
+	dict := val.(*PSDict)
+	if err := checkWrite(dict, "put"); err != nil {
+		return err
+	}
+
+	return dict.put(k, value)
+}
+
+// dOpKnown pushes whether a dictionary has an entry for a key
+func dOpKnown(i *Interpreter) error {
+
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	k, _ := i.opStack.Pop()
+	val, _ := i.opStack.Pop()
+
+	dict, ok := val.(*PSDict)
+	if !ok {
+		return typeCheck("known requires a dictionary")
+	}
+	if err := checkRead(dict, "known"); err != nil {
+		return err
+	}
+
+	_, found, err := dict.get(k)
+	if err != nil {
+		return err
+	}
+	i.opStack.Push(found)
+	return nil
+}
+
+// dOpWhere finds the topmost dictionary on the dict stack defining a key
+// pushes the dictionary and true, or just false if no dictionary defines it
+func dOpWhere(i *Interpreter) error {
+
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+
+	k, _ := i.opStack.Pop()
+	key, err := dictKey(k)
+	if err != nil {
+		return err
+	}
+
+	dict := i.dictWhere(key)
+	if dict == nil {
+		i.opStack.Push(false)
+		return nil
+	}
+	i.opStack.Push(dict)
+	i.opStack.Push(true)
+	return nil
+}
+
+// dOpStore replaces the value of a key in the topmost dictionary defining it
+// keys that aren't defined anywhere are defined in the current dictionary, like def
+func dOpStore(i *Interpreter) error {
+
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	value, _ := i.opStack.Pop()
+	k, _ := i.opStack.Pop()
+
+	key, err := dictKey(k)
+	if err != nil {
+		return err
+	}
+
+	dict := i.dictWhere(key)
+	if dict == nil {
+		dict = i.dictStack[len(i.dictStack)-1]
+	}
+	if err := checkWrite(dict, "store"); err != nil {
+		return err
+	}
+	return dict.put(k, value)
+}
+
+// dOpUndef removes a key from a dictionary, keys that aren't there are ignored
+func dOpUndef(i *Interpreter) error {
+
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	k, _ := i.opStack.Pop()
+	val, _ := i.opStack.Pop()
+
+	dict, ok := val.(*PSDict)
+	if !ok {
+		return typeCheck("undef requires a dictionary")
+	}
+	if err := checkWrite(dict, "undef"); err != nil {
+		return err
+	}
+
+	return dict.remove(k)
+}
+
+// dOpCurrentDict pushes the dictionary on top of the dict stack
+func dOpCurrentDict(i *Interpreter) error {
+	i.opStack.Push(i.dictStack[len(i.dictStack)-1])
+	return nil
+}
+
+// dOpCountDictStack pushes the number of dictionaries on the dict stack
+func dOpCountDictStack(i *Interpreter) error {
+	i.opStack.Push(len(i.dictStack))
+	return nil
+}
+
+// dOpDictStack stores the dict stack, bottom first, into an array and pushes the part of it that was filled
+func dOpDictStack(i *Interpreter) error {
+
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+
+	val, _ := i.opStack.Peek()
+	arr, ok := val.(PSArray)
+	if !ok {
+		return typeCheck("dictstack requires an array")
+	}
+	if err := checkWrite(arr, "dictstack"); err != nil {
+		return err
+	}
+	if len(arr.Items) < len(i.dictStack) {
+		return rangeCheck("array of length %d can't hold %d dictionaries", len(arr.Items), len(i.dictStack))
+	}
+
+	i.opStack.Pop()
+	for index, dict := range i.dictStack {
+		arr.Items[index] = dict
+	}
+	i.opStack.Push(arr.subarray(0, len(i.dictStack)))
+	return nil
+}
+
+// dOpClearDictStack pops every dictionary begun by the program, leaving systemdict and userdict
+func dOpClearDictStack(i *Interpreter) error {
+	i.dictStack = i.dictStack[:2]
+	return nil
+}
@@ -0,0 +1,488 @@
+package ps
+
+import (
+	"testing"
+)
+
+/*
+ -----------------------------------------------------------------------------
+	Note: Parts of these tests were drafted with the use of Generative AI.
+	All test content and logic has been reviewed and verified manually.
+ -----------------------------------------------------------------------------
+*/
+
+func TestOpDict(t *testing.T) {
+	// testing the creation of a dictionary with cap of 10
+	// expected value: dictionary with capacity of 10
+	tokens := []Token{
+		{Type: TOKEN_INT, Value: 10},
+		{Type: TOKEN_OPERATOR, Value: "dict"},
+	}
+
+	testInterpreter := executeTest(t, tokens)
+
+	// checking to see if stack has 1 item
+	if testInterpreter.opStack.StackCount() != 1 {
+		t.Errorf("Expected 1 item on stack, got %d", testInterpreter.opStack.StackCount())
+	}
+
+	// checking to see that item is a dictionary
+	top, _ := testInterpreter.opStack.Peek()
+	dict, ok := top.(*PSDict)
+	if !ok {
+		t.Fatalf("Expected *PSDict on top of stack, got %T", top)
+	}
+
+	// it is a dictionary - what's it's capacity?
+	if dict.capacity != 10 {
+		t.Errorf("Expected capacity 10, got %d", dict.capacity)
+	}
+
+	// checking that the map got initialized
+	if dict.items == nil {
+		t.Error("Expected items map to be initialized, got nil")
+	}
+
+	// making sure the dictionary at this stage is empty
+	if len(dict.items) != 0 {
+		t.Errorf("Expected empty dictionary, got %d items", len(dict.items))
+	}
+}
+
+func TestOpBegin(t *testing.T) {
+	// testing the addition of new dict to dict stack
+	// expected value: dictionary with cap 10 on the dict stack
+	tokens := []Token{
+		{Type: TOKEN_INT, Value: 10},
+		{Type: TOKEN_OPERATOR, Value: "dict"},
+		{Type: TOKEN_OPERATOR, Value: "begin"},
+	}
+
+	testInterpreter := CreateInterpreter()
+
+	// initial dict stack just has one dictionary (the global one)
+	initialDictStackSize := len(testInterpreter.dictStack)
+
+	err := testInterpreter.Execute(tokens)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	// executing tokens should add one to stack
+	if len(testInterpreter.dictStack) != initialDictStackSize+1 {
+		t.Errorf("Expected dict stack size %d, got %d", initialDictStackSize+1, len(testInterpreter.dictStack))
+	}
+
+	// dictionary added should have been taken from the opstack
+	if testInterpreter.opStack.StackCount() != 0 {
+		t.Errorf("Expected empty operand stack, got %d items", testInterpreter.opStack.StackCount())
+	}
+}
+
+func TestOpEnd(t *testing.T) {
+	// testing the definition of the end of a dictionary on dict stack
+	// dict stack should be +1 then back to initial size
+	tokens := []Token{
+		{Type: TOKEN_INT, Value: 10},
+		{Type: TOKEN_OPERATOR, Value: "dict"},
+		{Type: TOKEN_OPERATOR, Value: "begin"},
+		{Type: TOKEN_OPERATOR, Value: "end"},
+	}
+
+	testInterpreter := CreateInterpreter()
+	initialDictStackSize := len(testInterpreter.dictStack) // stack: [systemdict, userdict]
+
+	err := testInterpreter.Execute(tokens) // stack: [systemdict, userdict, newDict] >> [systemdict, userdict]
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	// dict stack should be back to original size
+	if len(testInterpreter.dictStack) != initialDictStackSize {
+		t.Errorf("Expected dict stack size %d, got %d", initialDictStackSize, len(testInterpreter.dictStack))
+	}
+}
+
+func TestOpDef(t *testing.T) {
+	// testing the definition of a variable or name binding
+	// expected value: x should be defined in the dictionary as 5
+	// note: converting to PSName in test cases bypassing tokenizer
+	tokens := []Token{
+		{Type: TOKEN_NAME, Value: PSName("x")}, // pushing /x as a name
+		{Type: TOKEN_INT, Value: 5},            // pushing 5
+		{Type: TOKEN_OPERATOR, Value: "def"},   // def operator setting x = 5
+	}
+
+	testInterpreter := executeTest(t, tokens)
+
+	// opstack should be empty at this point (after execution)
+	if testInterpreter.opStack.StackCount() != 0 {
+		t.Errorf("Expected empty operand stack after def, got %d items", testInterpreter.opStack.StackCount())
+	}
+
+	// top of dict stack (userdict in this case)
+	currentDict := testInterpreter.dictStack[len(testInterpreter.dictStack)-1]
+
+	// checking to see if x exists in the current dictionary
+	val, exists := currentDict.items["x"]
+	if !exists {
+		t.Fatal("Expected 'x' to be defined in dictionary, but it wasn't found")
+	}
+
+	// verifying the value of x is 5
+	if val != 5 {
+		t.Errorf("Expected x = 5, got x = %v", val)
+	}
+}
+
+func TestOpLengthDictionary(t *testing.T) {
+	testInterpreter := CreateInterpreter()
+	
+	// create dictionary 
+	dict := &PSDict{
+		items:    make(map[PSConstant]PSConstant),
+		capacity: 10,
+	}
+	// add 3 items
+	dict.items["x"] = 5
+	dict.items["y"] = 10
+	dict.items["z"] = 15
+	
+	testInterpreter.opStack.Push(dict)
+	
+	err := opLength(testInterpreter)
+	if err != nil {
+		t.Fatalf("opLength failed: %v", err)
+	}
+	
+	compareStackTop(t, testInterpreter, 3)
+}
+func TestOpEndUnderflow(t *testing.T) {
+	// systemdict and userdict should never be popped off
+	tokens := []Token{
+		{Type: TOKEN_OPERATOR, Value: "end"},
+	}
+
+	testInterpreter := CreateInterpreter()
+	err := testInterpreter.Execute(tokens)
+	if err == nil {
+		t.Error("Expected dict stack underflow error")
+	}
+}
+
+func TestOpLoadOperator(t *testing.T) {
+	// /add load should push the add operator itself
+	tokens := []Token{
+		{Type: TOKEN_NAME, Value: PSName("add")},
+		{Type: TOKEN_OPERATOR, Value: "load"},
+	}
+
+	testInterpreter := executeTest(t, tokens)
+	top, _ := testInterpreter.opStack.Peek()
+	op, ok := top.(*PSOperator)
+	if !ok {
+		t.Fatalf("Expected *PSOperator on top of stack, got %T", top)
+	}
+	if op.Name != "add" {
+		t.Errorf("Expected add operator, got %s", op.Name)
+	}
+}
+
+func TestRedefineOperator(t *testing.T) {
+	// /add {mul} def 3 4 add -> 12, the user definition shadows systemdict
+	tokens := []Token{
+		{Type: TOKEN_NAME, Value: PSName("add")},
+		{Type: TOKEN_BLOCK_START},
+		{Type: TOKEN_OPERATOR, Value: "mul"},
+		{Type: TOKEN_BLOCK_END},
+		{Type: TOKEN_OPERATOR, Value: "def"},
+		{Type: TOKEN_INT, Value: 3},
+		{Type: TOKEN_INT, Value: 4},
+		{Type: TOKEN_OPERATOR, Value: "add"},
+	}
+
+	testInterpreter := executeTest(t, tokens)
+	compareStackTop(t, testInterpreter, 12)
+
+	// systemdict itself is left untouched
+	if _, ok := testInterpreter.systemDict.items["add"].(*PSOperator); !ok {
+		t.Error("Expected systemdict add to still be an operator")
+	}
+}
+
+func TestDictFromMark(t *testing.T) {
+	// << /a 1 /b 2 >> should build a dictionary with both entries
+	testInterpreter := runTest(t, "<< /a 1 /b 2 >>")
+
+	top, _ := testInterpreter.opStack.Peek()
+	dict, ok := top.(*PSDict)
+	if !ok {
+		t.Fatalf("Expected *PSDict on top of stack, got %T", top)
+	}
+	if len(dict.items) != 2 || dict.items["a"] != 1 || dict.items["b"] != 2 {
+		t.Errorf("Expected a=1 b=2, got %v", dict.items)
+	}
+	compareStackCount(t, testInterpreter, 1)
+}
+
+func TestDictFromMarkOddCount(t *testing.T) {
+	testInterpreter := CreateInterpreter()
+	err := testInterpreter.Run("<< /a 1 /b >>")
+
+	psErr, ok := err.(*PSError)
+	if !ok || psErr.Name != "rangecheck" {
+		t.Errorf("Expected rangecheck, got %v", err)
+	}
+}
+
+func TestDictGetPut(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{"put then get", "/d 5 dict def d /a 1 put d /a get", 1},
+		{"put replaces", "/d 5 dict def d /a 1 put d /a 2 put d /a get", 2},
+		{"string key", "/d 5 dict def d (a) 1 put d (a) get", 1},
+		{"put seen through def", "/d 5 dict def d /a 3 put d begin a end", 3},
+		{"get from systemdict", "systemdict /userdict get userdict /x 4 put x", 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestDictGetMissingKey(t *testing.T) {
+	testInterpreter := CreateInterpreter()
+	err := testInterpreter.Run("5 dict /a get")
+
+	psErr, ok := err.(*PSError)
+	if !ok || psErr.Name != "undefined" {
+		t.Errorf("Expected undefined, got %v", err)
+	}
+}
+
+func TestOpKnown(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{"defined", "<< /a 1 >> /a known", true},
+		{"not defined", "<< /a 1 >> /b known", false},
+		{"operator in systemdict", "systemdict /add known", true},
+		{"not searched on the dict stack", "/x 1 def 5 dict /x known", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestOpWhere(t *testing.T) {
+	testInterpreter := runTest(t, "/x 1 def 5 dict begin /y 2 def /x where /y where /add where /nope where end")
+
+	stack := testInterpreter.Stack()
+	if len(stack) != 7 {
+		t.Fatalf("Expected 3 dictionary/true pairs and false, got %v", stack)
+	}
+	if stack[0] != testInterpreter.userDict || stack[1] != true {
+		t.Errorf("Expected x to be found in userdict, got %v %v", stack[0], stack[1])
+	}
+	if dict, ok := stack[2].(*PSDict); !ok || dict == testInterpreter.userDict || stack[3] != true {
+		t.Errorf("Expected y to be found in the begun dictionary, got %v %v", stack[2], stack[3])
+	}
+	if stack[4] != testInterpreter.systemDict || stack[5] != true {
+		t.Errorf("Expected add to be found in systemdict, got %v %v", stack[4], stack[5])
+	}
+	if stack[6] != false {
+		t.Errorf("Expected false for an undefined name, got %v", stack[6])
+	}
+}
+
+func TestOpStore(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{"replaces where defined", "/x 1 def 5 dict begin /x 2 store end x", 2},
+		{"defines in current dict when undefined", "5 dict begin /x 2 store currentdict end /x known", true},
+		{"does not shadow", "/x 1 def 5 dict begin /x 2 store currentdict end /x known", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestOpUndef(t *testing.T) {
+	testInterpreter := runTest(t, "/d << /a 1 /b 2 >> def d /a undef d /missing undef d /a known d length")
+	compareStackTop(t, testInterpreter, 1)
+	testInterpreter.opStack.Pop()
+	compareStackTop(t, testInterpreter, false)
+}
+
+func TestOpCurrentDict(t *testing.T) {
+	testInterpreter := runTest(t, "currentdict 5 dict dup begin currentdict end")
+
+	stack := testInterpreter.Stack()
+	if len(stack) != 3 || stack[0] != testInterpreter.userDict || stack[1] != stack[2] {
+		t.Errorf("Expected userdict followed by the begun dictionary twice, got %v", stack)
+	}
+}
+
+func TestOpCountDictStack(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{"systemdict and userdict", "countdictstack", 2},
+		{"after begin", "5 dict begin countdictstack", 3},
+		{"after cleardictstack", "5 dict begin 5 dict begin cleardictstack countdictstack", 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestOpDictStack(t *testing.T) {
+	testInterpreter := runTest(t, "5 dict begin 5 array dictstack")
+
+	top, _ := testInterpreter.opStack.Peek()
+	arr, ok := top.(PSArray)
+	if !ok || len(arr.Items) != 3 {
+		t.Fatalf("Expected an array of 3 dictionaries, got %v", top)
+	}
+	if arr.Items[0] != testInterpreter.systemDict || arr.Items[1] != testInterpreter.userDict || arr.Items[2] != testInterpreter.dictStack[2] {
+		t.Errorf("Expected the dict stack bottom first, got %v", arr.Items)
+	}
+}
+
+func TestDictOperatorErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1 /a known", "typecheck"},
+		{"<< >> null known", "typecheck"},
+		{"1 /a undef", "typecheck"},
+		{"null where", "typecheck"},
+		{"/x store", "stackunderflow"},
+		{"1 array dictstack", "rangecheck"},
+		{"1 dictstack", "typecheck"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			testInterpreter := CreateInterpreter()
+			err := testInterpreter.Run(test.input)
+
+			psErr, ok := err.(*PSError)
+			if !ok || psErr.Name != test.expected {
+				t.Errorf("Expected %s, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestDictKeys(t *testing.T) {
+	// any object can be a key, keys are the same when eq would say so
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{"integer", "<< 1 (one) >> 1 get", "one"},
+		{"boolean", "<< true 1 false 0 >> false get", 0},
+		{"real with integer value same as integer", "<< 1 (one) >> 1.0 get", "one"},
+		{"real", "<< 1.5 (x) >> 1.5 get", "x"},
+		{"name distinct from string", "<< /a 1 (a) 2 >> dup /a get exch (a) get add", 3},
+		{"string keys compared by contents", "<< (ab) 1 >> (ab) get", 1},
+		{"executable name same as literal", "<< /a 1 >> {a} 0 get get", 1},
+		{"same array", "/k [1 2] def << k (arr) >> k get", "arr"},
+		{"different array with same contents", "/k [1 2] def << k (arr) >> [1 2] known", false},
+		{"same empty array", "/k 0 array def << k (arr) >> k get", "arr"},
+		{"different empty array", "/d << [] (hit) >> def d 0 array known", false},
+		{"empty procedures are different", "<< {} 1 >> {} known", false},
+		{"subarray of the same part", "/k [1 2 3] def << k 1 1 getinterval 5 >> k 1 1 getinterval get", 5},
+		{"subarray of another part", "/k [1 2 3] def << k 1 1 getinterval 5 >> k 2 1 getinterval known", false},
+		{"empty subarrays at different places", "/k [1 2] def << k 0 0 getinterval 5 >> k 1 0 getinterval known", false},
+		{"dictionary", "/k 1 dict def << k 5 >> k get", 5},
+		{"later pair wins", "<< /a 1 /a 2 >> /a get", 2},
+		{"length counts distinct keys", "<< 1 0 1.0 0 /x 0 (x) 0 >> length", 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestDictForAllKeys(t *testing.T) {
+	// forall gives keys back as the objects they were made from
+	testInterpreter := runTest(t, "/k [7] def << (s) 1 /n 2 3 3 true 4 k 5 >> {pop} forall")
+
+	stack := testInterpreter.Stack()
+	if len(stack) != 5 {
+		t.Fatalf("expected 5 keys, got %v", stack)
+	}
+	expected := []PSConstant{PSName("n"), "s", 3, true}
+	for index := range expected {
+		if plainValue(stack[index]) != expected[index] {
+			t.Errorf("expected %v followed by the array, got %v", expected, stack)
+			break
+		}
+	}
+	if arr, ok := stack[4].(PSArray); !ok || len(arr.Items) != 1 || arr.Items[0] != 7 {
+		t.Errorf("expected the array key [7], got %v", stack[4])
+	}
+}
+
+func TestDictKeyOrder(t *testing.T) {
+	// keys with no value to sort by come after the others in the order they were first stored
+	tests := []struct {
+		name     string
+		input    string
+		expected []PSConstant
+	}{
+		{"arrays", "/a [1] def /b [2] def /c [3] def << c 3 a 1 b 2 >> {exch pop} forall", []PSConstant{3, 1, 2}},
+		{"dictionaries", "/d1 1 dict def /d2 1 dict def << d2 2 d1 1 >> {exch pop} forall", []PSConstant{2, 1}},
+		{"by kind first", "<< /add load 3 1 dict 2 [0] 1 /n 0 >> {exch pop} forall", []PSConstant{0, 1, 2, 3}},
+		{"replacing keeps the place", "/a [1] def /b [2] def /d << a 1 b 2 >> def d a 9 put d {exch pop} forall", []PSConstant{9, 2}},
+		{"stored again after undef goes last", "/a [1] def /b [2] def /d << a 1 b 2 >> def d a undef d a 1 put d {exch pop} forall", []PSConstant{2, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			stack := testInterpreter.Stack()
+			if len(stack) != len(test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, stack)
+			}
+			for index := range test.expected {
+				if stack[index] != test.expected[index] {
+					t.Errorf("expected %v, got %v", test.expected, stack)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package ps
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ======================================== PostScript errors
+
+// PSError is a PostScript error such as typecheck or stackunderflow
+// Name is the PostScript error name, Command is what was being executed when it happened
+// Pos is where in the source the offending token is, when known
+type PSError struct {
+	Name    string
+	Command string
+	Detail  string
+	Pos     Position
+	handled bool // set once errordict has been consulted so outer procedures don't handle it again
+}
+
+func (e *PSError) Error() string {
+	msg := e.Name
+	if e.Pos.IsValid() {
+		msg = e.Pos.String() + ": " + msg
+	}
+	if e.Command != "" {
+		msg += " in " + e.Command
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+// errStop is returned by the stop operator and unwinds execution to the nearest stopped
+var errStop = errors.New("stop")
+
+// errExit is returned by the exit operator and unwinds execution to the innermost loop
+var errExit = errors.New("exit")
+
+// error names that get a default handler in errordict
+var errorNames = []string{
+	"dictfull", "dictstackoverflow", "dictstackunderflow", "execstackoverflow",
+	"interrupt", "invalidaccess", "invalidexit", "invalidfileaccess",
+	"invalidrestore", "ioerror", "limitcheck", "rangecheck",
+	"stackoverflow", "stackunderflow", "syntaxerror", "timeout",
+	"typecheck", "undefined", "undefinedfilename", "undefinedresult",
+	"unmatchedmark", "unregistered", "VMerror",
+}
+
+// constructor for a PostScript error with the given name
+func newPSError(name string, format string, args ...any) *PSError {
+	return &PSError{Name: name, Detail: fmt.Sprintf(format, args...)}
+}
+
+// records where a PostScript error happened
+// the innermost position wins, so a position already set isn't overwritten as the error unwinds
+func withPosition(err error, pos Position) error {
+	var psErr *PSError
+	if pos.IsValid() && errors.As(err, &psErr) && !psErr.Pos.IsValid() {
+		psErr.Pos = pos
+	}
+	return err
+}
+
+// helpers for the errors operators raise most often
+func stackUnderflow() error {
+	return newPSError("stackunderflow", "not enough elements in stack")
+}
+
+func typeCheck(format string, args ...any) error {
+	return newPSError("typecheck", format, args...)
+}
+
+func rangeCheck(format string, args ...any) error {
+	return newPSError("rangecheck", format, args...)
+}
+
+func invalidAccess(format string, args ...any) error {
+	return newPSError("invalidaccess", format, args...)
+}
+
+// creates errordict with a default handler for every error and an empty $error
+// the default handlers stop, which unwinds to the nearest stopped
+func (i *Interpreter) registerErrorDicts() {
+	i.errorDict = &PSDict{
+		items:    make(map[PSConstant]PSConstant),
+		capacity: len(errorNames),
+	}
+	for _, name := range errorNames {
+		i.errorDict.items[name] = &PSOperator{Name: name, Fn: opErrorHandler}
+	}
+
+	i.errorState = &PSDict{
+		items:    make(map[PSConstant]PSConstant),
+		capacity: 10,
+	}
+	i.errorState.items["newerror"] = false
+
+	i.systemDict.items["errordict"] = i.errorDict
+	i.systemDict.items["$error"] = i.errorState
+}
+
+// default errordict handler
+// the command was already recorded in $error so it's discarded before stopping
+func opErrorHandler(i *Interpreter) error {
+	i.opStack.Pop()
+	return errStop
+}
+
+// records a PostScript error in $error and runs its handler from errordict
+// command is the operator or name that was being executed
+// returns nil if a user handler dealt with the error, otherwise the error to propagate
+func (i *Interpreter) handleError(err error, command PSConstant) error {
+	var psErr *PSError
+	if !errors.As(err, &psErr) || psErr.handled {
+		return err
+	}
+	psErr.handled = true
+	if psErr.Command == "" {
+		psErr.Command = fmt.Sprint(command)
+	}
+
+	// snapshot of the stacks at the time of the error
+	dictStack := make([]PSConstant, len(i.dictStack))
+	for index, dict := range i.dictStack {
+		dictStack[index] = dict
+	}
+
+	i.errorState.items["newerror"] = true
+	i.errorState.items["errorname"] = PSName(psErr.Name)
+	i.errorState.items["command"] = command
+	i.errorState.items["ostack"] = CreateArray(i.Stack())
+	i.errorState.items["dstack"] = CreateArray(dictStack)
+
+	// a handler raising the error it's handling would run itself forever, so that error just stops like the default
+	handler, ok := i.errorDict.items[psErr.Name]
+	if !ok || slices.Contains(i.handling, psErr.Name) {
+		return psErr
+	}
+
+	// handlers expect the offending command on the operand stack
+	i.opStack.Push(command)
+	i.handling = append(i.handling, psErr.Name)
+	handlerErr := i.execute(handler)
+	i.handling = i.handling[:len(i.handling)-1]
+	if errors.Is(handlerErr, errStop) {
+		return psErr
+	}
+	return handlerErr
+}
@@ -0,0 +1,320 @@
+package ps
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestErrorNames(t *testing.T) {
+	// each failure should surface as the matching PostScript error
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"add", "stackunderflow"},
+		{"1 (a) add", "typecheck"},
+		{"1 0 div", "undefinedresult"},
+		{"-4 sqrt", "rangecheck"},
+		{"nonexistent", "undefined"},
+		{"(a) {1} if", "typecheck"},
+		{"(a) dict", "typecheck"},
+		{"(abc) 5 get", "rangecheck"},
+		{"end", "dictstackunderflow"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			i := CreateInterpreter()
+			err := i.Run(test.input)
+
+			var psErr *PSError
+			if !errors.As(err, &psErr) {
+				t.Fatalf("expected *PSError, got %v", err)
+			}
+			if psErr.Name != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, psErr.Name)
+			}
+		})
+	}
+}
+
+func TestErrorCommand(t *testing.T) {
+	i := CreateInterpreter()
+	err := i.Run("1 (a) add")
+
+	var psErr *PSError
+	if !errors.As(err, &psErr) {
+		t.Fatalf("expected *PSError, got %v", err)
+	}
+	if psErr.Command != "--add--" {
+		t.Errorf("expected command --add--, got %s", psErr.Command)
+	}
+}
+
+func TestStoppedCatchesError(t *testing.T) {
+	// {1 (a) add} stopped -> true, execution carries on afterwards
+	i := CreateInterpreter()
+	err := i.Run("{1 (a) add} stopped 5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compareStackTop(t, i, 5)
+	i.opStack.Pop()
+	compareStackTop(t, i, true)
+}
+
+func TestStoppedNoError(t *testing.T) {
+	i := CreateInterpreter()
+	err := i.Run("{1 2 add} stopped")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compareStackCount(t, i, 2)
+	compareStackTop(t, i, false)
+}
+
+func TestStop(t *testing.T) {
+	// stop skips the rest of the procedure
+	i := CreateInterpreter()
+	err := i.Run("{1 stop 2} stopped")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compareStackCount(t, i, 2)
+	compareStackTop(t, i, true)
+}
+
+func TestStopNested(t *testing.T) {
+	// stop unwinds through nested procedures and loops
+	i := CreateInterpreter()
+	err := i.Run("{3 {true {stop} if} repeat} stopped")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compareStackCount(t, i, 1)
+	compareStackTop(t, i, true)
+}
+
+func TestErrorStatePopulated(t *testing.T) {
+	i := CreateInterpreter()
+	i.Run("{7 (a) mul} stopped")
+
+	if i.errorState.items["newerror"] != true {
+		t.Error("expected newerror to be true")
+	}
+	if i.errorState.items["errorname"] != PSName("typecheck") {
+		t.Errorf("expected errorname typecheck, got %v", i.errorState.items["errorname"])
+	}
+	op, ok := i.errorState.items["command"].(*PSOperator)
+	if !ok || op.Name != "mul" {
+		t.Errorf("expected command mul, got %v", i.errorState.items["command"])
+	}
+	// the operands of the failing operator are still on the stack when it's recorded
+	ostack, ok := i.errorState.items["ostack"].(PSArray)
+	if !ok || len(ostack.Items) != 2 || ostack.Items[0] != 7 || plainValue(ostack.Items[1]) != "a" {
+		t.Errorf("expected ostack to be the array [7 (a)], got %v", i.errorState.items["ostack"])
+	}
+}
+
+func TestErrorStateStacksAreArrays(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected PSConstant
+	}{
+		{"ostack type", "{1 (a) add} stopped pop $error /ostack get type", PSExecName("arraytype")},
+		{"ostack length", "{1 (a) add} stopped pop $error /ostack get length", 2},
+		{"dstack type", "{1 (a) add} stopped pop $error /dstack get type", PSExecName("arraytype")},
+		{"dstack holds the dict stack", "{1 (a) add} stopped pop $error /dstack get 0 get systemdict eq", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := runTest(t, test.input)
+			compareStackTop(t, i, test.expected)
+		})
+	}
+}
+
+func TestOperandsKeptOnError(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		count   int
+		operand PSConstant // the top operand left under stopped's result
+	}{
+		{"typecheck in add", "1 (a) {add} stopped", 3, "a"},
+		{"rangecheck in get", "[1 2] 5 {get} stopped", 3, 5},
+		{"undefined key in get", "<< /a 1 >> /b {get} stopped", 3, PSName("b")},
+		{"pushes of the failing operator undone", "1 2 {add (x) 1 put} stopped", 4, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := runTest(t, test.input)
+			compareStackCount(t, i, test.count)
+			compareStackTop(t, i, true)
+			i.opStack.Pop()
+			compareStackTop(t, i, test.operand)
+		})
+	}
+}
+
+func TestErrorDictOverride(t *testing.T) {
+	// a user handler that doesn't stop lets execution continue after the failing operator
+	i := CreateInterpreter()
+	// the handler is given the operands of add and add itself, and drops them all
+	err := i.Run("errordict begin /typecheck {pop pop pop 99} def end 1 (a) add 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compareStackCount(t, i, 2)
+	compareStackTop(t, i, 1)
+	i.opStack.Pop()
+	compareStackTop(t, i, 99)
+}
+
+// a handler that raises the error it's handling gets the default behaviour for it instead of running itself again
+func TestErrorHandlerReentry(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected PSConstant
+	}{
+		{"handler raising its own error", "errordict /typecheck { pop 1 (a) add } put { 1 (a) add } stopped", true},
+		{"error recorded", "errordict /typecheck { pop 1 (a) add } put { 1 (a) add } stopped pop $error /errorname get", PSName("typecheck")},
+		{"handler usable again afterwards", "errordict /typecheck { pop 1 (a) add } put { 1 (a) add } stopped pop errordict /typecheck { pop pop pop 5 } put 1 (a) add", 5},
+		{"handler raising another error", "errordict /rangecheck { pop pop pop 7 } put errordict /typecheck { pop pop pop [1] 5 get } put 1 (a) add", 7},
+		{"handlers raising each other's errors", "errordict /rangecheck { pop 1 (a) add } put errordict /typecheck { pop [1] 5 get } put { 1 (a) add } stopped", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := runTest(t, test.input)
+			compareStackTop(t, i, test.expected)
+		})
+	}
+}
+
+func TestUncaughtStop(t *testing.T) {
+	i := CreateInterpreter()
+	err := i.Run("stop")
+	if !errors.Is(err, errStop) {
+		t.Errorf("expected stop, got %v", err)
+	}
+}
+
+func TestErrorPosition(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Position
+		command  string
+	}{
+		{"top level operator", "1 2 add\n3 (a) mul", Position{Line: 2, Column: 7}, "--mul--"},
+		{"inside a procedure", "/f {\n  1 (a) add\n} def\nf", Position{Line: 2, Column: 9}, "--add--"},
+		{"undefined name in nested procedure", "/f { true { nope } if } def f", Position{Line: 1, Column: 13}, "nope"},
+		{"stray closing brace", "1 }", Position{Line: 1, Column: 3}, ""},
+		{"unclosed procedure", "1 2\n{ add", Position{Line: 2, Column: 1}, ""},
+		{"unclosed nested procedure", "{ 1 {\n2 }", Position{Line: 1, Column: 1}, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := CreateInterpreter()
+			err := testInterpreter.Run(test.input)
+
+			psErr, ok := err.(*PSError)
+			if !ok {
+				t.Fatalf("Expected a PSError, got %v", err)
+			}
+			if psErr.Pos != test.expected {
+				t.Errorf("Expected error at %v, got %v", test.expected, psErr.Pos)
+			}
+			if psErr.Command != test.command {
+				t.Errorf("Expected command %q, got %q", test.command, psErr.Command)
+			}
+		})
+	}
+}
+
+func TestUnclosedProcedurePositionInFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prog.ps")
+	if err := os.WriteFile(path, []byte("1 2\n{ add"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := CreateInterpreter().RunFile(path)
+	expected := path + ":2:1: syntaxerror"
+	if err == nil || !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("Expected error starting with %q, got %v", expected, err)
+	}
+}
+
+func TestErrorPositionInFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prog.ps")
+	if err := os.WriteFile(path, []byte("1 2 add\n(a) neg\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := CreateInterpreter().RunFile(path)
+	expected := path + ":2:5: typecheck in --neg--"
+	if err == nil || !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("Expected error starting with %q, got %v", expected, err)
+	}
+}
+
+// positions say which input they're in, so an error isn't matched up with the text of another one
+func TestErrorPositionSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		inputs   []string
+		expected Position
+	}{
+		{"current input", []string{"1 (a) add"}, Position{Source: "%stdin1", Line: 1, Column: 7}},
+		{"procedure from an earlier input", []string{"/f { 1 (a) add } def", "10 20 30 40 50 f"}, Position{Source: "%stdin1", Line: 1, Column: 12}},
+		{"executed string", []string{"(1 (a) add) cvx exec"}, Position{Source: stringSource, Line: 1, Column: 7}},
+		{"procedure scanned from a string", []string{"({(a) neg}) token pop exch pop exec"}, Position{Source: stringSource, Line: 1, Column: 6}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := CreateInterpreter()
+			var err error
+			for index, input := range test.inputs {
+				err = testInterpreter.RunInput(fmt.Sprintf("%%stdin%d", index+1), input)
+			}
+
+			psErr, ok := err.(*PSError)
+			if !ok {
+				t.Fatalf("Expected a PSError, got %v", err)
+			}
+			if psErr.Pos != test.expected {
+				t.Errorf("Expected error at %#v, got %#v", test.expected, psErr.Pos)
+			}
+		})
+	}
+}
+
+// running out of execution stack is recorded in $error and handled by errordict like other errors
+func TestExecStackOverflowHandled(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected PSConstant
+	}{
+		{"recorded in $error", "/m { m 1 } def { m } stopped pop $error /errorname get", PSName("execstackoverflow")},
+		{"user handler", "errordict /execstackoverflow { pop clear 42 stop } put /m { m 1 } def { m } stopped pop", 42},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			i := runTest(t, test.input)
+			compareStackTop(t, i, test.expected)
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package ps
+
+import (
+	"errors"
+	"io"
+)
+
+// ======================================== execution stack
+
+// programs are run from an explicit stack of frames rather than by Go recursion
+// each frame is a piece of work in progress: a procedure body, a file being read, a loop or a stopped context
+// control operators push frames and return, and runExecStack steps whatever frame is on top until they're all done
+// so deep (and tail) recursion in PostScript grows this stack instead of the Go stack
+
+// limit on the number of frames, past it execstackoverflow is raised
+const maxExecStack = 1000000
+
+// frames each running error handler may use past the limit, so execstackoverflow's handler has room to run
+const handlerExecStack = 1000
+
+type execFrame interface {
+	// step does the next piece of work, the frame pops itself off once it's finished
+	step(i *Interpreter) error
+	// object is how the frame shows up in execstack
+	object() PSConstant
+}
+
+// runs the items of a procedure one at a time
+type procedureFrame struct {
+	procedure  PSArray
+	index      int       // next item to execute
+	savedDicts []*PSDict // dict stack to put back when the frame is popped, nil if it wasn't swapped
+}
+
+// reads tokens from a file (or a slice of tokens) and executes them as they come
+type fileFrame struct {
+	source tokenSource
+	file   *PSFile // what currentfile returns while this frame is running
+}
+
+// control variable of for, the body is run until counter passes end
+type forFrame struct {
+	procedure PSArray
+	counter   float64
+	increment float64
+	end       float64
+	integers  bool // push the control variable as an integer
+}
+
+// body of repeat, run remaining more times
+type repeatFrame struct {
+	procedure PSArray
+	remaining int
+}
+
+// body of loop, run until exit
+type loopFrame struct {
+	procedure PSArray
+}
+
+// body of forall, run once for each group of elements
+type forallFrame struct {
+	procedure PSArray
+	elements  [][]PSConstant // values pushed before each run of the body
+	index     int
+}
+
+// set up by stopped, catches stop and errors from the frames above it
+type stoppedFrame struct{}
+
+func (f *procedureFrame) step(i *Interpreter) error {
+	items := f.procedure.Items
+	if f.index >= len(items) {
+		i.popFrame()
+		return nil
+	}
+	index := f.index
+	item := items[index]
+	f.index++
+
+	var err error
+	switch val := item.(type) {
+	case PSExecName:
+		err = i.executeName(string(val))
+	case *PSOperator: // already resolved by bind
+		err = i.executeObject(val)
+	default:
+		// nested procedures included, they're data until something executes them
+		i.opStack.Push(item)
+	}
+
+	return withPosition(err, f.procedure.positionOf(index))
+}
+
+// reports whether every item of the procedure has been executed
+func (f *procedureFrame) finished() bool {
+	return f.index >= len(f.procedure.Items)
+}
+
+// execstack shows the part of the procedure that's left to run
+func (f *procedureFrame) object() PSConstant {
+	rest := f.procedure
+	rest.Items = rest.Items[f.index:]
+	if len(rest.positions) > 0 {
+		rest.positions = rest.positions[f.index:]
+	}
+	return rest
+}
+
+func (f *fileFrame) step(i *Interpreter) error {
+	token, err := f.source.Next()
+	if err == io.EOF {
+		i.popFrame()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	obj, ok, err := i.tokenObject(token, f.source)
+	if err != nil || !ok {
+		return err
+	}
+
+	// a procedure written out in the program is data until something executes it
+	if procedure, isProc := obj.(PSArray); isProc && procedure.Executable {
+		i.opStack.Push(procedure)
+		return nil
+	}
+
+	// executable names are looked up and run, operators from //name run, literals are pushed
+	return withPosition(i.executeObject(obj), token.Pos)
+}
+
+func (f *fileFrame) object() PSConstant {
+	return f.file
+}
+
+func (f *forFrame) step(i *Interpreter) error {
+	// for loops inclusive to end number in PS, counting down for step values < 0
+	if (f.increment > 0 && f.counter > f.end) || (f.increment <= 0 && f.counter < f.end) {
+		i.popFrame()
+		return nil
+	}
+
+	if f.integers {
+		i.opStack.Push(int(f.counter))
+	} else {
+		i.opStack.Push(f.counter)
+	}
+	f.counter += f.increment
+	return i.pushLoopBody(f.procedure)
+}
+
+func (f *forFrame) object() PSConstant {
+	return f.procedure
+}
+
+func (f *repeatFrame) step(i *Interpreter) error {
+	if f.remaining <= 0 {
+		i.popFrame()
+		return nil
+	}
+	f.remaining--
+	return i.pushLoopBody(f.procedure)
+}
+
+func (f *repeatFrame) object() PSConstant {
+	return f.procedure
+}
+
+func (f *loopFrame) step(i *Interpreter) error {
+	return i.pushLoopBody(f.procedure)
+}
+
+func (f *loopFrame) object() PSConstant {
+	return f.procedure
+}
+
+func (f *forallFrame) step(i *Interpreter) error {
+	if f.index >= len(f.elements) {
+		i.popFrame()
+		return nil
+	}
+	for _, value := range f.elements[f.index] {
+		i.opStack.Push(value)
+	}
+	f.index++
+	return i.pushLoopBody(f.procedure)
+}
+
+func (f *forallFrame) object() PSConstant {
+	return f.procedure
+}
+
+// only reached when everything above it finished without stopping
+func (f *stoppedFrame) step(i *Interpreter) error {
+	i.popFrame()
+	i.opStack.Push(false)
+	return nil
+}
+
+func (f *stoppedFrame) object() PSConstant {
+	return PSExecName("stopped")
+}
+
+// reports whether exit ends the frame
+func isLoopFrame(frame execFrame) bool {
+	switch frame.(type) {
+	case *forFrame, *repeatFrame, *loopFrame, *forallFrame:
+		return true
+	}
+	return false
+}
+
+// puts a frame on top of the execution stack
+func (i *Interpreter) pushFrame(frame execFrame) error {
+	if len(i.execStack) >= maxExecStack+len(i.handling)*handlerExecStack {
+		return newPSError("execstackoverflow", "more than %d nested executions", maxExecStack)
+	}
+	i.execStack = append(i.execStack, frame)
+	return nil
+}
+
+// takes the top frame off the execution stack, putting back the dict stack it swapped out
+func (i *Interpreter) popFrame() {
+	top := len(i.execStack) - 1
+	if procedure, ok := i.execStack[top].(*procedureFrame); ok && procedure.savedDicts != nil {
+		i.dictStack = procedure.savedDicts
+	}
+	i.execStack[top] = nil
+	i.execStack = i.execStack[:top]
+}
+
+// schedules the body of a procedure to run next, swapping in the dict stack it captured if it's lexically scoped
+// a procedure called as the last thing another one does replaces it on the stack (a tail call),
+// taking over putting back the dict stack it swapped out, so tail recursion runs in constant space
+// everything that runs a procedure comes through here, so this is where execute access is checked
+func (i *Interpreter) pushProcedure(procedure PSArray) error {
+	if !procedure.access.canExecute() {
+		return invalidAccess("can't execute a noaccess procedure")
+	}
+
+	frame := &procedureFrame{procedure: procedure}
+
+	top := len(i.execStack) - 1
+	if top >= i.execBase {
+		if caller, ok := i.execStack[top].(*procedureFrame); ok && caller.finished() {
+			frame.savedDicts = caller.savedDicts
+			i.execStack[top] = nil
+			i.execStack = i.execStack[:top]
+		}
+	}
+
+	err := i.pushFrame(frame)
+	if err != nil {
+		return err
+	}
+
+	if procedure.CapturedDicts != nil {
+		if frame.savedDicts == nil {
+			frame.savedDicts = i.dictStack
+		}
+		// copied so begin and end inside the procedure don't write over what it captured
+		i.dictStack = append([]*PSDict(nil), procedure.CapturedDicts...)
+	}
+	return nil
+}
+
+// schedules the next run of a loop body, an execstackoverflow is passed to errordict like an operator's error
+func (i *Interpreter) pushLoopBody(procedure PSArray) error {
+	if err := i.pushProcedure(procedure); err != nil {
+		return i.handleError(err, procedure)
+	}
+	return nil
+}
+
+// a copy of the current dict stack for a procedure to capture
+func (i *Interpreter) captureDicts() []*PSDict {
+	return append([]*PSDict(nil), i.dictStack...)
+}
+
+// pushes frame and runs until it and everything it started are finished
+func (i *Interpreter) runFrame(frame execFrame) error {
+	base := len(i.execStack)
+	defer i.setExecBase(base)()
+
+	err := i.pushFrame(frame)
+	if err != nil {
+		return err
+	}
+	return i.runExecStack(base)
+}
+
+// executes obj and runs until everything it started is finished
+// used where Go code needs the result straight away, like running an error handler
+func (i *Interpreter) execute(obj PSConstant) error {
+	base := len(i.execStack)
+	defer i.setExecBase(base)()
+
+	if err := i.executeObject(obj); err != nil {
+		if err = i.unwind(base, err); err != nil {
+			return err
+		}
+	}
+	return i.runExecStack(base)
+}
+
+// marks the frames below base as belonging to an outer runExecStack, which the inner one mustn't touch
+// returns a function putting the previous base back
+func (i *Interpreter) setExecBase(base int) func() {
+	saved := i.execBase
+	i.execBase = base
+	return func() { i.execBase = saved }
+}
+
+// steps the frame on top of the execution stack until the stack is back down to base
+func (i *Interpreter) runExecStack(base int) error {
+	for len(i.execStack) > base {
+		if i.quit {
+			for len(i.execStack) > base {
+				i.popFrame()
+			}
+			return nil
+		}
+
+		err := i.execStack[len(i.execStack)-1].step(i)
+		if err != nil {
+			if err = i.unwind(base, err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pops frames after an error until one of them deals with it
+// stopped catches stop and PostScript errors (pushing true), loops catch exit
+// returns nil if the error was caught above base, otherwise the error once the stack is back down to base
+func (i *Interpreter) unwind(base int, err error) error {
+	var psErr *PSError
+	catchStop := errors.Is(err, errStop) || errors.As(err, &psErr)
+	catchExit := errors.Is(err, errExit)
+
+	for len(i.execStack) > base {
+		frame := i.execStack[len(i.execStack)-1]
+		i.popFrame()
+
+		if _, ok := frame.(*stoppedFrame); ok && catchStop {
+			i.opStack.Push(true)
+			return nil
+		}
+		if isLoopFrame(frame) && catchExit {
+			return nil
+		}
+	}
+	return err
+}
+
+// the file belonging to the innermost file being read
+func (i *Interpreter) currentFile() *PSFile {
+	for index := len(i.execStack) - 1; index >= 0; index-- {
+		if frame, ok := i.execStack[index].(*fileFrame); ok {
+			return frame.file
+		}
+	}
+	return &PSFile{}
+}
@@ -0,0 +1,341 @@
+package ps
+
+// ======================================== flow control operators
+
+// executes procedure if leading bool val is true
+func opIf(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+	proc, _ := i.opStack.Pop()
+	boolVar, _ := i.opStack.Pop()
+
+	// converting stack object to procedure and bool var to boolean value
+	procedure, okProc := proc.(PSArray)
+	conditionalBool, okBool := boolVar.(bool)
+	if !okProc || !okBool {
+		return typeCheck("if requires a boolean and a procedure")
+	}
+
+	if conditionalBool {
+		return i.pushProcedure(procedure)
+	}
+	return nil
+}
+
+// executes procedure #1 if leading bool val is true, executes procedure #2 otherwise
+func opIfElse(i *Interpreter) error {
+	if i.opStack.StackCount() < 3 {
+		return stackUnderflow()
+	}
+	proc2, _ := i.opStack.Pop()
+	proc1, _ := i.opStack.Pop()
+	boolVar, _ := i.opStack.Pop()
+
+	// converting values
+	procedure1, okProc1 := proc1.(PSArray)
+	procedure2, okProc2 := proc2.(PSArray)
+	conditionalBool, okBool := boolVar.(bool)
+	if !okProc1 || !okProc2 || !okBool {
+		return typeCheck("ifelse requires a boolean and two procedures")
+	}
+
+	if conditionalBool {
+		return i.pushProcedure(procedure1)
+	}
+	return i.pushProcedure(procedure2)
+}
+
+// executes procedure in a loop according to a start/stop/step index
+// the control variable is an integer when all three are integers and a real otherwise
+func opFor(i *Interpreter) error {
+	if i.opStack.StackCount() < 4 {
+		return stackUnderflow()
+	}
+	proc, _ := i.opStack.Pop()
+	endVar, _ := i.opStack.Pop()
+	stepVar, _ := i.opStack.Pop()
+	startVar, _ := i.opStack.Pop()
+
+	// converting + initializing counter variable
+	procedure, okProc := proc.(PSArray)          // func to be executed
+	step, errStep := convertToNumber(stepVar)    // the number by which count is incremented
+	start, errStart := convertToNumber(startVar) // starting index
+	end, errEnd := convertToNumber(endVar)       // ending index
+	if !okProc || errStep != nil || errStart != nil || errEnd != nil {
+		return typeCheck("for requires three numbers and a procedure")
+	}
+	_, intStep := stepVar.(int)
+	_, intStart := startVar.(int)
+	_, intEnd := endVar.(int)
+
+	return i.pushFrame(&forFrame{
+		procedure: procedure,
+		counter:   start,
+		increment: step,
+		end:       end,
+		integers:  intStep && intStart && intEnd,
+	})
+}
+
+// repeats a procedure n times
+func opRepeat(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	proc, _ := i.opStack.Pop()
+	n, _ := i.opStack.Pop()
+
+	procedure, okProc := proc.(PSArray) // func to be executed
+	num, okNum := n.(int)               // number of times
+	if !okProc || !okNum {
+		return typeCheck("repeat requires an integer and a procedure")
+	}
+	if num < 0 {
+		return rangeCheck("repeat count cannot be negative")
+	}
+
+	return i.pushFrame(&repeatFrame{procedure: procedure, remaining: num})
+}
+
+// repeats a procedure until exit (or stop, or an error) ends it
+func opLoop(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	proc, _ := i.opStack.Pop()
+
+	procedure, ok := proc.(PSArray)
+	if !ok {
+		return typeCheck("loop requires a procedure")
+	}
+
+	return i.pushFrame(&loopFrame{procedure: procedure})
+}
+
+// runs a procedure for each element of an array, string or dictionary
+// arrays push each element, strings each character code, dictionaries each key and value
+func opForAll(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+	proc, _ := i.opStack.Pop()
+	collection, _ := i.opStack.Pop()
+
+	procedure, ok := proc.(PSArray)
+	if !ok {
+		return typeCheck("forall requires a procedure")
+	}
+
+	if err := checkRead(collection, "forall"); err != nil {
+		return err
+	}
+
+	// the elements pushed before each run of the procedure
+	var elements [][]PSConstant
+	switch val := collection.(type) {
+	case PSArray:
+		for _, item := range val.Items {
+			elements = append(elements, []PSConstant{item})
+		}
+	case PSString:
+		for _, char := range val.Bytes {
+			elements = append(elements, []PSConstant{int(char)})
+		}
+	case *PSDict:
+		// keys are sorted so the order is the same every time
+		for _, key := range val.sortedKeys() {
+			elements = append(elements, []PSConstant{val.keyObject(key), val.items[key]})
+		}
+	default:
+		return typeCheck("forall requires an array, string or dictionary")
+	}
+
+	return i.pushFrame(&forallFrame{procedure: procedure, elements: elements})
+}
+
+// terminates the innermost enclosing loop
+// it's an error if there isn't one, or if stopped is in the way
+func opExit(i *Interpreter) error {
+	for index := len(i.execStack) - 1; index >= 0; index-- {
+		frame := i.execStack[index]
+		if isLoopFrame(frame) {
+			return errExit
+		}
+		if _, ok := frame.(*stoppedFrame); ok {
+			break
+		}
+	}
+	return newPSError("invalidexit", "exit is not inside a loop")
+}
+
+// quits the application
+func opQuit(i *Interpreter) error {
+
+	i.quit = true
+	return nil
+}
+
+// unwinds execution to the nearest enclosing stopped
+func opStop(i *Interpreter) error {
+	return errStop
+}
+
+// executes a procedure, pushing true if it was ended by stop or an error and false otherwise
+func opStopped(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	proc, _ := i.opStack.Pop()
+
+	// the stopped frame sits under the procedure and pushes the result once it's done
+	err := i.pushFrame(&stoppedFrame{})
+	if err != nil {
+		return err
+	}
+	return i.executeObject(proc)
+}
+
+// executes some arbitrary object/procedure
+// literal objects are pushed back onto the stack unchanged
+func opExec(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	obj, _ := i.opStack.Pop()
+
+	return i.executeObject(obj)
+}
+
+// replaces executable names in a procedure that resolve to operators with the operators themselves
+// nested procedures are bound as well
+func opBind(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	proc, _ := i.opStack.Peek()
+
+	procedure, ok := proc.(PSArray)
+	if !ok {
+		return typeCheck("bind requires procedure")
+	}
+
+	i.bindProcedure(procedure)
+	return nil
+}
+
+// binds a procedure in place, recursing into nested procedures
+// procedures that can't be changed are left as they are
+func (i *Interpreter) bindProcedure(procedure PSArray) {
+	if !procedure.access.canWrite() {
+		return
+	}
+	for index, item := range procedure.Items {
+		switch val := item.(type) {
+		case PSArray:
+			if val.Executable {
+				i.bindProcedure(val)
+			}
+		case PSExecName:
+			value, err := i.dictLookup(string(val))
+			if err != nil {
+				continue // names not defined yet are left alone
+			}
+			if op, ok := value.(*PSOperator); ok {
+				procedure.Items[index] = op
+			}
+		}
+	}
+}
+
+// makes a procedure lexically scoped by capturing the current dict stack for it to run in
+// nested procedures that aren't lexical yet capture it too, the original procedure is left as it was
+func opLexBind(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	proc, _ := i.opStack.Pop()
+
+	procedure, ok := proc.(PSArray)
+	if !ok || !procedure.Executable {
+		return typeCheck("lexbind requires procedure")
+	}
+
+	i.opStack.Push(i.lexBindProcedure(procedure, i.captureDicts()))
+	return nil
+}
+
+// returns a copy of procedure capturing dicts, replacing whatever it captured when it was read,
+// so in lexical mode a procedure made inside another can capture the dictionaries begun to make it
+// the items are copied too so nested procedures can be replaced without changing the original's
+func (i *Interpreter) lexBindProcedure(procedure PSArray, dicts []*PSDict) PSArray {
+	items := make([]PSConstant, len(procedure.Items))
+	for index, item := range procedure.Items {
+		if nested, ok := item.(PSArray); ok && nested.Executable {
+			item = i.lexBindProcedure(nested, dicts)
+		}
+		items[index] = item
+	}
+	procedure.Items = items
+	procedure.storage = &arrayStorage{}
+	procedure.start = 0
+	procedure.CapturedDicts = dicts
+	return procedure
+}
+
+// sets whether procedures written from now on are lexically (true) or dynamically (false) scoped
+// procedures that already exist keep the scoping they were written with
+func opSetLexical(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	lexical, ok := val.(bool)
+	if !ok {
+		return typeCheck("setlexical requires a boolean")
+	}
+
+	i.lexicalMode = lexical
+	return nil
+}
+
+// pushes true if procedures written now are lexically scoped, false if dynamically
+func opCurrentLexical(i *Interpreter) error {
+	i.opStack.Push(i.lexicalMode)
+	return nil
+}
+
+// pushes the number of frames on the execution stack
+func opCountExecStack(i *Interpreter) error {
+	i.opStack.Push(len(i.execStack))
+	return nil
+}
+
+// stores the execution stack, bottom first, into an array and pushes the part of it that was filled
+// each frame shows up as what it's running: the rest of a procedure, a loop body, a file or stopped
+func opExecStack(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Peek()
+
+	arr, ok := val.(PSArray)
+	if !ok {
+		return typeCheck("execstack requires an array")
+	}
+	if err := checkWrite(arr, "execstack"); err != nil {
+		return err
+	}
+	if len(arr.Items) < len(i.execStack) {
+		return rangeCheck("array of length %d can't hold %d frames", len(arr.Items), len(i.execStack))
+	}
+
+	i.opStack.Pop()
+	for index, frame := range i.execStack {
+		arr.Items[index] = frame.object()
+	}
+	i.opStack.Push(arr.subarray(0, len(i.execStack)))
+	return nil
+}
@@ -0,0 +1,427 @@
+package ps
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+ -----------------------------------------------------------------------------
+	Note: Parts of these tests were drafted with the use of Generative AI.
+	All test content and logic has been reviewed and verified manually.
+ -----------------------------------------------------------------------------
+*/
+
+func TestOpIf(t *testing.T) {
+	// testing if block
+	// expected value: 3 from {1 2 add} block
+	i := CreateInterpreter()
+
+	tokens := []Token{
+		// stack: [true, {1 2 add}, if]
+		{Type: TOKEN_BOOL, Value: true},
+		{Type: TOKEN_BLOCK_START},
+		{Type: TOKEN_INT, Value: 1},
+		{Type: TOKEN_INT, Value: 2},
+		{Type: TOKEN_OPERATOR, Value: "add"},
+		{Type: TOKEN_BLOCK_END},
+		{Type: TOKEN_OPERATOR, Value: "if"},
+	}
+	i.Execute(tokens)
+	compareStackTop(t, i, 3)
+}
+
+func TestOpIfElse(t *testing.T) {
+	// testing ifelse block
+	// expected value: -1 from {1 2 sub} block
+	i := CreateInterpreter()
+
+	tokens := []Token{
+		// stack: [false, {1 2 add}, {1 2 sub}, ifelse]
+		{Type: TOKEN_BOOL, Value: false},
+		// {1 2 add} as the if block/procedure
+		{Type: TOKEN_BLOCK_START},
+		{Type: TOKEN_INT, Value: 1},
+		{Type: TOKEN_INT, Value: 2},
+		{Type: TOKEN_OPERATOR, Value: "add"},
+		{Type: TOKEN_BLOCK_END},
+		// {1 2 sub} as else block procedure
+		{Type: TOKEN_BLOCK_START},
+		{Type: TOKEN_INT, Value: 1},
+		{Type: TOKEN_INT, Value: 2},
+		{Type: TOKEN_OPERATOR, Value: "sub"},
+		{Type: TOKEN_BLOCK_END},
+		{Type: TOKEN_OPERATOR, Value: "ifelse"},
+	}
+	i.Execute(tokens)
+	compareStackTop(t, i, -1)
+}
+
+func TestOpFor(t *testing.T) {
+	// testing for loop
+	// expected value: 3 at the top of the stack
+	i := CreateInterpreter()
+
+	tokens := []Token{
+		// stack: [0, 1, 3, {}, for]
+		{Type: TOKEN_INT, Value: 0}, // starting value
+		{Type: TOKEN_INT, Value: 1}, // step value
+		{Type: TOKEN_INT, Value: 3}, // ending value
+		// { } - empty block should just push counter onto the stack
+		{Type: TOKEN_BLOCK_START},
+		{Type: TOKEN_BLOCK_END},
+		{Type: TOKEN_OPERATOR, Value: "for"},
+	}
+	i.Execute(tokens)
+	compareStackTop(t, i, 3)
+}
+
+func TestOpRepeat(t *testing.T) {
+	// testing repeat function
+	// expected value: 2 at the top of the stack
+	i := CreateInterpreter()
+
+	tokens := []Token{
+		// stack: [1, 2, 3, 4, 5, 3, {=}, repeat]
+		{Type: TOKEN_INT, Value: 1},
+		{Type: TOKEN_INT, Value: 2},
+		{Type: TOKEN_INT, Value: 3},
+		{Type: TOKEN_INT, Value: 4},
+		{Type: TOKEN_INT, Value: 5},
+		// 3 {=} repeat - should execute print procedure 3 times
+		{Type: TOKEN_INT, Value: 3}, // n
+		{Type: TOKEN_BLOCK_START},
+		{Type: TOKEN_OPERATOR, Value: "="}, // proc
+		{Type: TOKEN_BLOCK_END},
+		{Type: TOKEN_OPERATOR, Value: "repeat"},
+	}
+	i.Execute(tokens)
+	compareStackTop(t, i, 2)
+}
+
+func TestOpQuit(t *testing.T) {
+	// testing the quit function
+	// Expected result is that interpreter should not make it executing the third line
+	i := CreateInterpreter()
+	tokens := []Token{
+		{Type: TOKEN_INT, Value: 1}, // pushes to stack
+		{Type: TOKEN_OPERATOR, Value: "quit"},
+		{Type: TOKEN_INT, Value: 2}, // should not execute
+	}
+
+	i.Execute(tokens)
+	if !i.quit {
+		t.Fatal("quit flag expected to be true")
+	}
+
+	compareStackCount(t, i, 1)
+	compareStackTop(t, i, 1)
+}
+
+func TestOpBind(t *testing.T) {
+	// /p {1 2 add} bind def /add {mul} def p -> 3, add was resolved when bound
+	i := CreateInterpreter()
+
+	tokens := []Token{
+		{Type: TOKEN_NAME, Value: PSName("p")},
+		{Type: TOKEN_BLOCK_START},
+		{Type: TOKEN_INT, Value: 1},
+		{Type: TOKEN_INT, Value: 2},
+		{Type: TOKEN_OPERATOR, Value: "add"},
+		{Type: TOKEN_BLOCK_END},
+		{Type: TOKEN_OPERATOR, Value: "bind"},
+		{Type: TOKEN_OPERATOR, Value: "def"},
+		{Type: TOKEN_NAME, Value: PSName("add")},
+		{Type: TOKEN_BLOCK_START},
+		{Type: TOKEN_OPERATOR, Value: "mul"},
+		{Type: TOKEN_BLOCK_END},
+		{Type: TOKEN_OPERATOR, Value: "def"},
+		{Type: TOKEN_OPERATOR, Value: "p"},
+	}
+	err := i.Execute(tokens)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	compareStackTop(t, i, 3)
+}
+
+func TestOpForReals(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []PSConstant
+	}{
+		{"real step", "0 0.5 1.5 {} for", []PSConstant{0.0, 0.5, 1.0, 1.5}},
+		{"real start", "1.0 1 3 {} for", []PSConstant{1.0, 2.0, 3.0}},
+		{"real limit", "1 1 2.5 {} for", []PSConstant{1.0, 2.0}},
+		{"integers stay integers", "3 -1 1 {} for", []PSConstant{3, 2, 1}},
+		{"no iterations", "5 1 1 {} for", []PSConstant{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stack := runTest(t, test.input).Stack()
+			if len(stack) != len(test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, stack)
+			}
+			for index := range test.expected {
+				if plainValue(stack[index]) != test.expected[index] {
+					t.Errorf("expected %v, got %v", test.expected, stack)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestOpForTypeCheck(t *testing.T) {
+	testInterpreter := CreateInterpreter()
+	err := testInterpreter.Run("1 (a) 5 {} for")
+
+	psErr, ok := err.(*PSError)
+	if !ok || psErr.Name != "typecheck" {
+		t.Errorf("Expected typecheck, got %v", err)
+	}
+}
+
+func TestOpLoopExit(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{"loop until exit", "0 {1 add dup 5 eq {exit} if} loop", 5},
+		{"exit from for", "0 1 1 100 {dup 4 gt {pop exit} if add} for", 10},
+		{"exit from repeat", "0 10 {1 add dup 3 eq {exit} if} repeat", 3},
+		{"exit from forall", "0 [1 2 3 4] {dup 3 eq {pop exit} if add} forall", 3},
+		{"exit only leaves inner loop", "0 3 {{1 add exit} loop} repeat", 3},
+		{"stop passes through loops", "{0 {1 add dup 3 eq {stop} if} loop} stopped", true},
+		{"exit inside stopped inside loop", "{{exit} stopped exit} loop", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestOpExitOutsideLoop(t *testing.T) {
+	inputs := []string{"exit", "{exit} exec", "/f {exit} def f"}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			testInterpreter := CreateInterpreter()
+			err := testInterpreter.Run(input)
+
+			psErr, ok := err.(*PSError)
+			if !ok || psErr.Name != "invalidexit" {
+				t.Errorf("Expected invalidexit, got %v", err)
+			}
+		})
+	}
+}
+
+func TestOpForAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []PSConstant
+	}{
+		{"array", "[1 (two) /three] {} forall", []PSConstant{1, "two", PSName("three")}},
+		{"string", "(AB) {} forall", []PSConstant{65, 66}},
+		{"dictionary", "<< /b 2 /a 1 >> {} forall", []PSConstant{PSName("a"), 1, PSName("b"), 2}},
+		{"empty array", "[] {1} forall", []PSConstant{}},
+		{"sum", "0 [1 2 3] {add} forall", []PSConstant{6}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stack := runTest(t, test.input).Stack()
+			if len(stack) != len(test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, stack)
+			}
+			for index := range test.expected {
+				if plainValue(stack[index]) != test.expected[index] {
+					t.Errorf("expected %v, got %v", test.expected, stack)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestQuitEndsLoop(t *testing.T) {
+	testInterpreter := runTest(t, "{1 quit} loop 2")
+	compareStackCount(t, testInterpreter, 1)
+	if !testInterpreter.Quit() {
+		t.Error("Expected quit to be set")
+	}
+}
+
+func TestDeepRecursion(t *testing.T) {
+	// recursion grows the execution stack rather than the Go stack, and tail calls don't grow it at all
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{"tail recursion", "/f {dup 0 gt {1 sub f} if} def 1000000 f", 0},
+		{"non-tail recursion", "/f {dup 0 gt {1 sub f 1 add} if} def 100000 f", 100000},
+	}
+
+	for _, test := range tests {
+		for _, lexical := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s lexical=%v", test.name, lexical), func(t *testing.T) {
+				testInterpreter := New(Options{Lexical: lexical})
+				err := testInterpreter.Run(test.input)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				compareStackTop(t, testInterpreter, test.expected)
+			})
+		}
+	}
+}
+
+func TestExecStackOverflow(t *testing.T) {
+	// recursion that never ends is an error stopped can catch
+	testInterpreter := runTest(t, "/f {f 1} def {f} stopped")
+	compareStackTop(t, testInterpreter, true)
+	compareStackCount(t, testInterpreter, 1)
+	if len(testInterpreter.execStack) != 0 {
+		t.Errorf("Expected an empty execution stack, got %d frames", len(testInterpreter.execStack))
+	}
+}
+
+func TestOpCountExecStack(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{"top level", "countexecstack", 1},
+		{"inside procedure", "{countexecstack} exec", 2},
+		{"inside loop", "1 {countexecstack} repeat", 3},
+		{"inside stopped", "{countexecstack} stopped pop", 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestOpExecStack(t *testing.T) {
+	testInterpreter := runTest(t, "{5 array execstack 1 2} exec")
+	stack := testInterpreter.Stack()
+	if len(stack) != 3 {
+		t.Fatalf("expected execstack result followed by 1 2, got %v", stack)
+	}
+
+	frames, ok := stack[0].(PSArray)
+	if !ok || len(frames.Items) != 2 {
+		t.Fatalf("expected an array of 2 frames, got %v", stack[0])
+	}
+	if _, ok := frames.Items[0].(*PSFile); !ok {
+		t.Errorf("expected the file at the bottom, got %v", frames.Items[0])
+	}
+	rest, ok := frames.Items[1].(PSArray)
+	if !ok || len(rest.Items) != 2 || rest.Items[0] != 1 || rest.Items[1] != 2 {
+		t.Errorf("expected the rest of the procedure {1 2}, got %v", frames.Items[1])
+	}
+}
+
+func TestOpExecStackTooSmall(t *testing.T) {
+	testInterpreter := CreateInterpreter()
+	err := testInterpreter.Run("{0 array execstack} exec")
+
+	psErr, ok := err.(*PSError)
+	if !ok || psErr.Name != "rangecheck" {
+		t.Errorf("Expected rangecheck, got %v", err)
+	}
+}
+
+func TestOpSetLexical(t *testing.T) {
+	// each procedure keeps the scoping it was written with
+	testInterpreter := runTest(t, "/x 1 def true setlexical /lshow {x} def false setlexical /dshow {x} def "+
+		"10 dict begin /x 2 def lshow dshow end")
+
+	expected := []PSConstant{1, 2}
+	stack := testInterpreter.Stack()
+	if len(stack) != 2 || stack[0] != expected[0] || stack[1] != expected[1] {
+		t.Errorf("expected %v, got %v", expected, stack)
+	}
+}
+
+func TestOpCurrentLexical(t *testing.T) {
+	tests := []struct {
+		name     string
+		lexical  bool
+		input    string
+		expected any
+	}{
+		{"dynamic by default", false, "currentlexical", false},
+		{"lexical option", true, "currentlexical", true},
+		{"after setlexical", false, "true setlexical currentlexical", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := New(Options{Lexical: test.lexical})
+			err := testInterpreter.Run(test.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestOpLexBind(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{"procedure", "/x 1 def /show {x} lexbind def 10 dict begin /x 2 def show end", 1},
+		{"nested procedure", "/x 1 def /mk {{x}} lexbind def 10 dict begin /x 2 def mk exec end", 1},
+		{"captured where lexbind runs", "/x 1 def 10 dict begin /x 2 def {x} lexbind end /show exch def show", 2},
+		{"without lexbind", "/x 1 def /show {x} def 10 dict begin /x 2 def show end", 2},
+		{"original nested procedure left dynamic", "/x 1 def /p {{x} exec} def /q /p load lexbind def 10 dict begin /x 2 def p end", 2},
+		{"copy nested procedure lexical", "/x 1 def /p {{x} exec} def /q /p load lexbind def 10 dict begin /x 2 def q end", 1},
+		{"readonly procedure", "/x 1 def /p {{x} exec} readonly lexbind def 10 dict begin /x 2 def p end", 1},
+		{"closure in dynamic mode", "/mk { 10 dict begin /n exch def { n } lexbind end } def 1 mk exec", 1},
+		{"closure in lexical mode", "true setlexical /mk { 10 dict begin /n exch def { n } lexbind end } def 1 mk exec", 1},
+		{"closures keep their own dict", "true setlexical /mk { 10 dict begin /n exch def { n } lexbind end } def /a 1 mk def /b 2 mk def a b add", 3},
+		{"lexical procedure captured again", "true setlexical /x 1 def /show {x} def 10 dict begin /x 2 def /show load lexbind end exec", 2},
+		{"nested lexical procedure captured again", "true setlexical /x 1 def /mk {{x}} def 10 dict begin /x 2 def /mk load lexbind end exec exec", 2},
+		{"original lexical procedure unchanged", "true setlexical /x 1 def /show {x} def 10 dict begin /x 2 def /show load lexbind pop end show", 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestScopingTypeCheck(t *testing.T) {
+	inputs := []string{"1 lexbind", "[1 2] lexbind", "1 setlexical"}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			testInterpreter := CreateInterpreter()
+			err := testInterpreter.Run(input)
+
+			psErr, ok := err.(*PSError)
+			if !ok || psErr.Name != "typecheck" {
+				t.Errorf("Expected typecheck, got %v", err)
+			}
+		})
+	}
+}
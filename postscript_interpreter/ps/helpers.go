@@ -0,0 +1,20 @@
+package ps
+
+import (
+	"fmt"
+	"math"
+)
+
+// ====================================== helper functions to assist in conversions
+
+// allows for interface types to be converted into numbers to perform operations on
+func convertToNumber(num PSConstant) (float64, error) {
+	switch val := num.(type) {
+	case int:
+		return float64(val), nil
+	case float64:
+		return val, nil
+	default:
+		return math.NaN(), fmt.Errorf("incorrect input")
+	}
+}
@@ -1,26 +1,12 @@
-package main
+package ps
 
 import (
 	"bytes"
-	"fmt"
-	"math"
 	"os"
 	"testing"
 )
 
-// ====================================== helper functions to assist in conversions/test executions
-
-// allows for interface types to be converted into numbers to perform operations on
-func convertToNumber(num PSConstant) (float64, error) {
-	switch val := num.(type) {
-	case int:
-		return float64(val), nil
-	case float64:
-		return val, nil
-	default:
-		return math.NaN(), fmt.Errorf("incorrect input")
-	}
-}
+// ====================================== helper functions to assist in test executions
 
 // helper to capture stdout for input/output operations tests
 func captureOutput(f func()) string {
@@ -53,17 +39,43 @@ func executeTest(t *testing.T, tokens []Token) *Interpreter {
 	return testInterpreter
 }
 
+// helper function to create interpreter and run source code
+func runTest(t *testing.T, src string) *Interpreter {
+	testInterpreter := CreateInterpreter()
+	err := testInterpreter.Run(src)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	return testInterpreter
+}
+
 // helper to cross check top of stack with expected value
 func compareStackTop(t *testing.T, testInterpreter *Interpreter, expected any) {
 	if testInterpreter.opStack.StackCount() == 0 {
 		t.Fatalf("Stack is empty, expected %v", expected)
 	}
 	top, _ := testInterpreter.opStack.Peek()
-	if top != expected {
+	if plainValue(top) != expected {
 		t.Errorf("Expected %v on top of stack, got %v", expected, top)
 	}
 }
 
+// test tables write strings as Go strings, this turns them into the interpreter's strings
+func testValue(value any) PSConstant {
+	if text, ok := value.(string); ok {
+		return CreateString(text)
+	}
+	return value
+}
+
+// the reverse of testValue, strings are compared by their text
+func plainValue(value PSConstant) any {
+	if str, ok := value.(PSString); ok {
+		return str.String()
+	}
+	return value
+}
+
 // helper to cross check stack count with expected value
 func compareStackCount(t *testing.T, interp *Interpreter, expected int) {
 	count := interp.opStack.StackCount()
@@ -0,0 +1,232 @@
+package ps
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ======================================== input/output operators
+
+// writes characters of string to stdout
+func opPrint(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	v, _ := i.opStack.Pop()
+	str, ok := v.(PSString)
+	if !ok {
+		return typeCheck("print requires a string")
+	}
+	if err := checkRead(str, "print"); err != nil {
+		return err
+	}
+	i.output().Write(str.Bytes)
+
+	return nil
+}
+
+// writes text representation of any to stdout
+func opEquals(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	v, _ := i.opStack.Pop()
+	// the text of other composite objects is --nostringval--, only a string's contents are read
+	if isString(v) {
+		if err := checkRead(v, "="); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(i.output(), formatText(v))
+
+	return nil
+}
+
+// destructive display of top of stack
+func opEqualsEquals(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	v, _ := i.opStack.Pop()
+	if err := checkRead(v, "=="); err != nil {
+		return err
+	}
+	fmt.Fprintln(i.output(), formatObject(v))
+
+	return nil
+}
+
+// reads and executes the PostScript file named by a string
+func opRun(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	v, _ := i.opStack.Pop()
+	path, ok := v.(PSString)
+	if !ok {
+		return typeCheck("run requires a file name string")
+	}
+
+	return i.RunFile(path.String())
+}
+
+// pushes the file the program is currently being read from
+// reading from it with token picks up right after the currentfile that's being executed
+func opCurrentFile(i *Interpreter) error {
+	i.opStack.Push(i.currentFile())
+	return nil
+}
+
+// reads a single object from a file or a string
+// file token → any true | false
+// string token → post any true | false, post being what's left of the string after the object
+func opToken(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	v, _ := i.opStack.Pop()
+
+	switch source := v.(type) {
+	case *PSFile:
+		if source.tokenizer == nil {
+			i.opStack.Push(false)
+			return nil
+		}
+		obj, ok, err := i.scanObject(source.tokenizer)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// nothing left in the file
+			source.tokenizer = nil
+			i.opStack.Push(false)
+			return nil
+		}
+		i.opStack.Push(obj)
+		i.opStack.Push(true)
+
+	case PSString:
+		if err := checkRead(source, "token"); err != nil {
+			return err
+		}
+		tokenizer := createSourceTokenizer(stringSource, source.String())
+		obj, ok, err := i.scanObject(tokenizer)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			i.opStack.Push(false)
+			return nil
+		}
+		// the rest of the string shares its storage, like getinterval
+		i.opStack.Push(source.substring(tokenizer.offset, len(source.Bytes)))
+		i.opStack.Push(obj)
+		i.opStack.Push(true)
+
+	default:
+		return typeCheck("token requires a file or a string")
+	}
+	return nil
+}
+
+// text representation used by =
+// composite objects have no plain text form
+func formatText(obj PSConstant) string {
+	switch val := obj.(type) {
+	case PSArray, *PSDict, *PSFile:
+		return "--nostringval--"
+	case float64:
+		return formatReal(val)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// reals always show a decimal point so they can't be mistaken for integers, e.g. 5.0
+func formatReal(num float64) string {
+	text := strconv.FormatFloat(num, 'g', -1, 64)
+	if strings.ContainsAny(text, ".eIN") {
+		return text
+	}
+	return text + ".0"
+}
+
+// text representation used by ==
+// strings are shown in parentheses, literal names with a slash and arrays and dictionaries with their contents
+func formatObject(obj PSConstant) string {
+	return formatNested(obj, map[PSConstant]bool{})
+}
+
+// the contents of a string written with the escapes the tokenizer reads, so == output can be scanned back in
+// backslashes and parentheses are escaped, as are control characters and bytes outside ASCII (as \ddd octal)
+func escapeString(data []byte) string {
+	var text strings.Builder
+	for _, ch := range data {
+		switch ch {
+		case '\\', '(', ')':
+			text.WriteByte('\\')
+			text.WriteByte(ch)
+		case '\n':
+			text.WriteString(`\n`)
+		case '\r':
+			text.WriteString(`\r`)
+		case '\t':
+			text.WriteString(`\t`)
+		case '\b':
+			text.WriteString(`\b`)
+		case '\f':
+			text.WriteString(`\f`)
+		default:
+			if ch < ' ' || ch > '~' {
+				fmt.Fprintf(&text, "\\%03o", ch)
+			} else {
+				text.WriteByte(ch)
+			}
+		}
+	}
+	return text.String()
+}
+
+// formatObject for an object inside the arrays and dictionaries in seen, which are being shown already
+// one that contains itself is shown as -array- or -dict- the second time round instead of going on forever
+func formatNested(obj PSConstant, seen map[PSConstant]bool) string {
+	switch val := obj.(type) {
+	case PSString:
+		return "(" + escapeString(val.Bytes) + ")"
+	case PSName:
+		return "/" + string(val)
+	case PSArray:
+		key, _ := dictKey(val)
+		if seen[key] && len(val.Items) > 0 {
+			return "-array-"
+		}
+		seen[key] = true
+		defer delete(seen, key)
+
+		parts := make([]string, len(val.Items))
+		for index, item := range val.Items {
+			parts[index] = formatNested(item, seen)
+		}
+		if val.Executable {
+			return "{" + strings.Join(parts, " ") + "}"
+		}
+		return "[" + strings.Join(parts, " ") + "]"
+	case *PSDict:
+		if seen[val] {
+			return "-dict-"
+		}
+		seen[val] = true
+		defer delete(seen, val)
+
+		parts := []string{"<<"}
+		for _, key := range val.sortedKeys() {
+			parts = append(parts, formatNested(val.keyObject(key), seen), formatNested(val.items[key], seen))
+		}
+		return strings.Join(append(parts, ">>"), " ")
+	case *PSFile:
+		return val.String()
+	default:
+		return formatText(val)
+	}
+}
@@ -0,0 +1,297 @@
+package ps
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+/*
+ -----------------------------------------------------------------------------
+	Note: Parts of these tests were drafted with the use of Generative AI.
+	All test content and logic has been reviewed and verified manually.
+ ----------------------------------------------------------------------------- 
+*/ 
+
+
+func TestOpPrint(t *testing.T) {
+	testInterpreter := CreateInterpreter()
+	testInterpreter.opStack.Push(CreateString("hello"))
+
+	output := captureOutput(func() {
+		opPrint(testInterpreter)
+	})
+
+	if output != "hello" {
+		t.Errorf("Expected 'hello', got '%s'", output)
+	}
+}
+
+func TestOpEquals(t *testing.T) {
+	testInterpreter := CreateInterpreter()
+	testInterpreter.opStack.Push(42)
+
+	output := captureOutput(func() {
+		opEquals(testInterpreter)
+	})
+
+	if output != "42\n" {
+		t.Errorf("Expected '42\\n', got '%s'", output)
+	}
+}
+
+func TestOpEqualsEqualsString(t *testing.T) {
+	testInterpreter := CreateInterpreter()
+	testInterpreter.opStack.Push(CreateString("hello"))
+
+	output := captureOutput(func() {
+		opEqualsEquals(testInterpreter)
+	})
+
+	if output != "(hello)\n" {
+		t.Errorf("Expected '(hello)\\n', got '%s'", output)
+	}
+}
+
+func TestOpEqualsEqualsNumber(t *testing.T) {
+	testInterpreter := CreateInterpreter()
+	testInterpreter.opStack.Push(42)
+
+	output := captureOutput(func() {
+		opEqualsEquals(testInterpreter)
+	})
+
+	if output != "42\n" {
+		t.Errorf("Expected '42\\n', got '%s'", output)
+	}
+}
+
+func TestOpEqualsReal(t *testing.T) {
+	// reals always print with a decimal point so they aren't mistaken for integers
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{5.0, "5.0\n"},
+		{2.5, "2.5\n"},
+		{-3.0, "-3.0\n"},
+	}
+
+	for _, test := range tests {
+		testInterpreter := CreateInterpreter()
+		testInterpreter.opStack.Push(testValue(test.value))
+
+		output := captureOutput(func() {
+			opEquals(testInterpreter)
+		})
+
+		if output != test.expected {
+			t.Errorf("Expected %q, got %q", test.expected, output)
+		}
+	}
+}
+
+func TestOpRun(t *testing.T) {
+	// a procedure spanning several lines in a file
+	path := filepath.Join(t.TempDir(), "square.ps")
+	src := "/square {\n  dup mul\n} def\n% a comment\n7 square\n"
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("writing test file: %v", err)
+	}
+
+	testInterpreter := CreateInterpreter()
+	testInterpreter.opStack.Push(CreateString(path))
+	err := opRun(testInterpreter)
+	if err != nil {
+		t.Fatalf("unexpected run error: %v", err)
+	}
+	compareStackTop(t, testInterpreter, 49)
+}
+
+func TestOpRunMissingFile(t *testing.T) {
+	testInterpreter := CreateInterpreter()
+	err := testInterpreter.RunFile(filepath.Join(t.TempDir(), "missing.ps"))
+
+	psErr, ok := err.(*PSError)
+	if !ok || psErr.Name != "undefinedfilename" {
+		t.Errorf("Expected undefinedfilename, got %v", err)
+	}
+}
+
+func TestArguments(t *testing.T) {
+	testInterpreter := New(Options{Args: []string{"first", "second"}})
+	err := testInterpreter.Run("ARGUMENTS length ARGUMENTS 1 get")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compareStackTop(t, testInterpreter, "second")
+	testInterpreter.opStack.Pop()
+	compareStackTop(t, testInterpreter, 2)
+}
+
+func TestOpTokenString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []PSConstant
+	}{
+		{"number followed by string", "(15(St1) {1 2 add}) token", []PSConstant{"(St1) {1 2 add}", 15, true}},
+		{"string followed by procedure", "((St1) {1 2 add}) token", []PSConstant{" {1 2 add}", "St1", true}},
+		{"whitespace after name consumed", "(abc 123) token", []PSConstant{"123", PSExecName("abc"), true}},
+		{"literal name", "(/x) token", []PSConstant{"", PSName("x"), true}},
+		{"only whitespace", "( ) token", []PSConstant{false}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stack := runTest(t, test.input).Stack()
+			if len(stack) != len(test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, stack)
+			}
+			for index := range test.expected {
+				if plainValue(stack[index]) != test.expected[index] {
+					t.Errorf("expected %v, got %v", test.expected, stack)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestOpTokenProcedure(t *testing.T) {
+	// a procedure is read as one object, not executed
+	testInterpreter := runTest(t, "( {1 2 add} rest) token pop exec")
+	compareStackTop(t, testInterpreter, 3)
+}
+
+func TestOpCurrentFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{"reads the next object", "currentfile token 42 pop", 42},
+		{"skips inline data", "/skip {currentfile token pop pop} def skip (data) (after)", "after"},
+		{"end of file", "currentfile token", false},
+		{"procedure read as data", "currentfile token {1 2 add} pop exec", 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestOpCurrentFileInRunFile(t *testing.T) {
+	// token reads from the file being run, not from the program that ran it
+	path := filepath.Join(t.TempDir(), "inline.ps")
+	if err := os.WriteFile(path, []byte("currentfile token\n99\npop\n"), 0o644); err != nil {
+		t.Fatalf("writing test file: %v", err)
+	}
+
+	testInterpreter := CreateInterpreter()
+	testInterpreter.opStack.Push(CreateString(path))
+	err := testInterpreter.Run("run 5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []PSConstant{99, 5}
+	stack := testInterpreter.Stack()
+	if len(stack) != 2 || stack[0] != expected[0] || stack[1] != expected[1] {
+		t.Errorf("expected %v, got %v", expected, stack)
+	}
+}
+
+func TestRunReader(t *testing.T) {
+	// the program runs as it is read, so code before a syntax error has already executed
+	testInterpreter := CreateInterpreter()
+	err := testInterpreter.RunReader("stream.ps", strings.NewReader("1 2 add\n(unterminated"))
+
+	psErr, ok := err.(*PSError)
+	if !ok || psErr.Name != "syntaxerror" || psErr.Pos.File != "stream.ps" || psErr.Pos.Line != 2 {
+		t.Errorf("Expected syntaxerror at stream.ps:2, got %v", err)
+	}
+	compareStackTop(t, testInterpreter, 3)
+}
+
+func TestOpEqualsEqualsEscapes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain", "(abc) ==", "(abc)\n"},
+		{"newline", "(a\\nb) ==", "(a\\nb)\n"},
+		{"other whitespace escapes", "(\\r\\t\\b\\f) ==", "(\\r\\t\\b\\f)\n"},
+		{"unbalanced parenthesis", "(a\\)) ==", "(a\\))\n"},
+		{"balanced parentheses", "(a(b)c) ==", "(a\\(b\\)c)\n"},
+		{"backslash", "(a\\\\b) ==", "(a\\\\b)\n"},
+		{"control bytes", "(\\000\\033) ==", "(\\000\\033)\n"},
+		{"bytes outside ascii", "<7fff> ==", "(\\177\\377)\n"},
+		{"inside an array", "[(a\\nb)] ==", "[(a\\nb)]\n"},
+		{"dictionary key", "<< (\\)) 1 >> ==", "<< (\\)) 1 >>\n"},
+		{"= writes the bytes as they are", "(a\\)) =", "a)\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := CreateInterpreter()
+			output := captureOutput(func() {
+				if err := testInterpreter.Run(test.input); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+			if output != test.expected {
+				t.Errorf("Expected %q, got %q", test.expected, output)
+			}
+		})
+	}
+}
+
+// what == writes reads back in as the same string
+func TestOpEqualsEqualsRoundTrip(t *testing.T) {
+	original := "a\nb)\\(\x00\xff ~"
+	testInterpreter := CreateInterpreter()
+	testInterpreter.opStack.Push(CreateString(original))
+	output := captureOutput(func() {
+		opEqualsEquals(testInterpreter)
+	})
+
+	tokens, err := CreateTokenizer(output).Tokenize()
+	if err != nil || len(tokens) != 1 || tokens[0].Value != original {
+		t.Errorf("Expected %q to scan back as %q, got %v (%v)", output, original, tokens, err)
+	}
+}
+
+func TestOpEqualsEqualsDict(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"entries", "<< /b (x) /a 1 >> ==", "<< /a 1 /b (x) >>\n"},
+		{"empty", "<< >> ==", "<< >>\n"},
+		{"non-name keys", "<< 2 /two (s) [1] true 1.5 >> ==", "<< (s) [1] 2 /two true 1.5 >>\n"},
+		{"nested", "<< /d << /x {dup} >> >> ==", "<< /d << /x {dup} >> >>\n"},
+		{"contains itself", "/d 1 dict def d /self d put d ==", "<< /self -dict- >>\n"},
+		{"array contains itself", "/a 1 array def a 0 a put a ==", "[-array-]\n"},
+		{"= has no text for dictionaries", "<< /a 1 >> =", "--nostringval--\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := CreateInterpreter()
+			output := captureOutput(func() {
+				if err := testInterpreter.Run(test.input); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			})
+			if output != test.expected {
+				t.Errorf("Expected %q, got %q", test.expected, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,494 @@
+package ps
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+type Interpreter struct {
+	opStack     *Stack    // operand stack
+	dictStack   []*PSDict // stack of dictionaries
+	lexicalMode bool      // whether procedures being read capture the dict stack, i.e. are lexically scoped
+	systemDict  *PSDict   // built-in operators, bottom of the dict stack
+	userDict    *PSDict   // default dictionary for user definitions
+	errorDict   *PSDict   // errordict, handlers for each error name
+	errorState  *PSDict   // $error, details of the most recent error
+	handling    []string  // names of the errors whose errordict handlers are running, innermost last
+	quit        bool
+	stdout      io.Writer   // destination for print/=/==, nil means os.Stdout
+	execStack   []execFrame // work in progress, the top frame is what runs next
+	execBase    int         // frames below this belong to an outer run of the execution stack
+	randState   int         // state of the random number generator, set by srand
+}
+
+// Options configures an interpreter created through New
+type Options struct {
+	Lexical bool      // procedures are lexically scoped instead of dynamically, until changed with setlexical
+	Stdout  io.Writer // where output operators write to, defaults to os.Stdout
+	Args    []string  // command line arguments, available to programs as the ARGUMENTS array
+}
+
+// New creates an interpreter configured with the given options
+func New(opts Options) *Interpreter {
+	interpreter := CreateInterpreter()
+	interpreter.lexicalMode = opts.Lexical
+	interpreter.stdout = opts.Stdout
+
+	args := make([]PSConstant, len(opts.Args))
+	for index, arg := range opts.Args {
+		args[index] = CreateString(arg)
+	}
+	interpreter.userDict.items["ARGUMENTS"] = CreateArray(args)
+	return interpreter
+}
+
+// function acting like a constructor
+func CreateInterpreter() *Interpreter {
+	// initializing systemdict (operators) and userdict (global definitions)
+	systemDict := &PSDict{
+		items: make(map[PSConstant]PSConstant),
+	}
+	userDict := &PSDict{
+		items:    make(map[PSConstant]PSConstant),
+		capacity: 100,
+	}
+
+	// initializing interpreter
+	interpreter := &Interpreter{
+		opStack:     CreateStack(),
+		dictStack:   []*PSDict{systemDict, userDict},
+		lexicalMode: false,
+		systemDict:  systemDict,
+		userDict:    userDict,
+		randState:   1,
+	}
+
+	// populating systemdict with all the available operators
+	interpreter.registerOperators()
+	interpreter.registerErrorDicts()
+	systemDict.items["systemdict"] = systemDict
+	systemDict.items["userdict"] = userDict
+	systemDict.capacity = len(systemDict.items)
+
+	// read only so programs can't redefine or remove the built-in operators, they can still shadow them
+	systemDict.access = readOnlyAccess
+	return interpreter
+}
+
+// stores a built-in operator in systemdict under its name
+func (i *Interpreter) register(name string, fn func(*Interpreter) error) {
+	i.systemDict.items[name] = &PSOperator{Name: name, Fn: fn}
+}
+
+// populating systemdict with the operators and their associated functions
+func (i *Interpreter) registerOperators() {
+
+	// arithmetic
+	i.register("add", opAdd)
+	i.register("sub", opSub)
+	i.register("mul", opMul)
+	i.register("div", opDiv)
+	i.register("idiv", opIntdiv)
+	i.register("mod", opMod)
+	i.register("abs", opAbs)
+	i.register("neg", opNeg)
+	i.register("sqrt", opSqrt)
+	i.register("ceiling", opCeil)
+	i.register("floor", opFloor)
+	i.register("round", opRound)
+	i.register("truncate", opTruncate)
+	i.register("sin", opSin)
+	i.register("cos", opCos)
+	i.register("atan", opAtan)
+	i.register("exp", opExp)
+	i.register("ln", opLn)
+	i.register("log", opLog)
+	i.register("rand", opRand)
+	i.register("srand", opSrand)
+	i.register("rrand", opRrand)
+
+	// stack manipulation
+	i.register("dup", opDup)
+	i.register("pop", opPop)
+	i.register("exch", opExch)
+	i.register("clear", opClear)
+	i.register("count", opCount)
+	i.register("mark", opMark)
+	i.register("counttomark", opCountToMark)
+	i.register("cleartomark", opClearToMark)
+	i.register("index", opIndex)
+	i.register("copy", opCopy)
+	i.register("roll", opRoll)
+	i.register("pstack", opPstack)
+	i.register("stack", opStack)
+
+	// comparison
+	i.register("eq", opEq)
+	i.register("ne", opNe)
+	i.register("gt", opGt)
+	i.register("ge", opGe)
+	i.register("lt", opLt)
+	i.register("le", opLe)
+
+	// boolean
+	i.register("and", opAnd)
+	i.register("or", opOr)
+	i.register("not", opNot)
+	i.register("xor", opXor)
+	i.register("bitshift", opBitshift)
+	i.register("true", opTrue)
+	i.register("false", opFalse)
+
+	// dictionary
+	i.register("dict", dOpDict)
+	i.register("begin", dOpBegin)
+	i.register("end", dOpEnd)
+	i.register("def", dOpDef)
+	i.register("length", opLength)
+	i.register("maxlength", dOpMaxLength)
+	i.register("load", dOpLoad)
+	i.register("<<", opMark)
+	i.register(">>", dOpDictFromMark)
+	i.register("known", dOpKnown)
+	i.register("where", dOpWhere)
+	i.register("store", dOpStore)
+	i.register("undef", dOpUndef)
+	i.register("currentdict", dOpCurrentDict)
+	i.register("countdictstack", dOpCountDictStack)
+	i.register("dictstack", dOpDictStack)
+	i.register("cleardictstack", dOpClearDictStack)
+
+	// flow control
+	i.register("if", opIf)
+	i.register("ifelse", opIfElse)
+	i.register("for", opFor)
+	i.register("repeat", opRepeat)
+	i.register("loop", opLoop)
+	i.register("exit", opExit)
+	i.register("forall", opForAll)
+	i.register("quit", opQuit)
+	i.register("exec", opExec)
+	i.register("stop", opStop)
+	i.register("stopped", opStopped)
+	i.register("bind", opBind)
+	i.register("execstack", opExecStack)
+	i.register("countexecstack", opCountExecStack)
+
+	// scoping
+	i.register("lexbind", opLexBind)
+	i.register("setlexical", opSetLexical)
+	i.register("currentlexical", opCurrentLexical)
+
+	// access attributes
+	i.register("readonly", opReadOnly)
+	i.register("executeonly", opExecuteOnly)
+	i.register("noaccess", opNoAccess)
+	i.register("rcheck", opRcheck)
+	i.register("wcheck", opWcheck)
+
+	// conversion
+	i.register("cvx", opCvx)
+	i.register("cvlit", opCvlit)
+	i.register("xcheck", opXcheck)
+	i.register("cvi", opCvi)
+	i.register("cvr", opCvr)
+	i.register("cvn", opCvn)
+	i.register("cvs", opCvs)
+	i.register("cvrs", opCvrs)
+	i.register("type", opType)
+
+	// input/output
+	i.register("print", opPrint)
+	i.register("=", opEquals)
+	i.register("==", opEqualsEquals)
+	i.register("run", opRun)
+	i.register("currentfile", opCurrentFile)
+	i.register("token", opToken)
+
+	// string operations
+	i.register("get", opGet)
+	i.register("getinterval", opGetInterval)
+	i.register("putinterval", opPutInterval)
+	i.register("put", opPut)
+	i.register("string", opString)
+	i.register("search", opSearch)
+	i.register("anchorsearch", opAnchorSearch)
+
+	// arrays
+	i.register("[", opMark)
+	i.register("]", opArrayFromMark)
+	i.register("array", opArray)
+	i.register("aload", opAload)
+	i.register("astore", opAstore)
+	i.register("null", opNull)
+}
+
+// Run tokenizes the source string and executes it
+func (i *Interpreter) Run(src string) error {
+	return i.runSource(CreateTokenizer(src))
+}
+
+// RunInput is Run for one of several inputs given to the same interpreter, like the lines typed into a REPL
+// source is recorded in error positions, so an error can be matched up with the input it happened in
+func (i *Interpreter) RunInput(source string, src string) error {
+	return i.runSource(createSourceTokenizer(source, src))
+}
+
+// RunReader executes a program read from reader as it goes, without loading it all into memory
+// name is the file name used in error positions and can be empty
+func (i *Interpreter) RunReader(name string, reader io.Reader) error {
+	return i.runSource(CreateReaderTokenizer(name, reader))
+}
+
+// executes the tokens read by tokenizer, which is the current file while it runs
+func (i *Interpreter) runSource(tokenizer *Tokenizer) error {
+	return i.runFrame(&fileFrame{source: tokenizer, file: &PSFile{tokenizer: tokenizer}})
+}
+
+// RunFile reads a PostScript program from a file and executes it
+func (i *Interpreter) RunFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return newPSError("undefinedfilename", "%v", err)
+	}
+	defer file.Close()
+	return i.RunReader(path, file)
+}
+
+// Stack returns a copy of the operand stack, bottom first
+func (i *Interpreter) Stack() []PSConstant {
+	items := make([]PSConstant, len(i.opStack.items))
+	copy(items, i.opStack.items)
+	return items
+}
+
+// StackCount returns the number of items on the operand stack
+func (i *Interpreter) StackCount() int {
+	return i.opStack.StackCount()
+}
+
+// Lexical reports whether procedures read from now on are lexically scoped
+func (i *Interpreter) Lexical() bool {
+	return i.lexicalMode
+}
+
+// SetLexical chooses lexical (true) or dynamic (false) scoping for procedures read from now on,
+// the same as the setlexical operator; procedures that were already read keep their scoping
+func (i *Interpreter) SetLexical(lexical bool) {
+	i.lexicalMode = lexical
+}
+
+// Quit reports whether the quit operator has been executed
+func (i *Interpreter) Quit() bool {
+	return i.quit
+}
+
+// output returns the writer used by the output operators
+// note: resolved on each call so os.Stdout can be swapped out (tests rely on this)
+func (i *Interpreter) output() io.Writer {
+	if i.stdout != nil {
+		return i.stdout
+	}
+	return os.Stdout
+}
+
+// helper function to be able to search for a value through the dict stack
+func (i *Interpreter) dictLookup(name string) (PSConstant, error) {
+	index := len(i.dictStack) - 1
+	for index >= 0 {
+		if val, ok := i.dictStack[index].items[name]; ok {
+			return val, nil
+		}
+		index--
+	}
+	return nil, newPSError("undefined", "%s is not defined in dictionary stack", name)
+}
+
+// finds the topmost dictionary on the dict stack defining key (as given by dictKey), nil if none does
+func (i *Interpreter) dictWhere(key PSConstant) *PSDict {
+	for index := len(i.dictStack) - 1; index >= 0; index-- {
+		if _, ok := i.dictStack[index].items[key]; ok {
+			return i.dictStack[index]
+		}
+	}
+	return nil
+}
+
+// looks up the value of an immediately evaluated //name
+// found is false when the name was undefined and an error handler recovered from it
+func (i *Interpreter) immediateLookup(name string) (PSConstant, bool, error) {
+	value, err := i.dictLookup(name)
+	if err != nil {
+		return nil, false, i.handleError(err, PSName(name))
+	}
+	return value, true, nil
+}
+
+// looks up a name on the dict stack and executes what it finds
+func (i *Interpreter) executeName(name string) error {
+	value, err := i.dictLookup(name)
+	if err != nil {
+		return i.handleError(err, PSExecName(name))
+	}
+	return i.executeObject(value)
+}
+
+// executes an object according to its literal/executable attribute
+// operators are run, executable procedures and strings are pushed onto the execution stack to run next,
+// executable names are looked up, anything literal is pushed
+func (i *Interpreter) executeObject(obj PSConstant) error {
+	switch val := obj.(type) {
+	case *PSOperator:
+		i.opStack.checkpoint()
+		err := val.Fn(i)
+		if err != nil {
+			// like PostScript, an operator that fails leaves its operands on the stack for the error handler
+			var psErr *PSError
+			if errors.As(err, &psErr) && !psErr.handled {
+				i.opStack.restore()
+			}
+			return i.handleError(err, val)
+		}
+		return nil
+	case PSArray:
+		if val.Executable {
+			// a noaccess procedure or too deep a recursion is an error like any other, recorded in $error and passed to errordict
+			if err := i.pushProcedure(val); err != nil {
+				return i.handleError(err, val)
+			}
+			return nil
+		}
+	case PSString:
+		// read and run like a file, currentfile still being the file the string was executed from
+		if val.Executable {
+			if !val.access.canExecute() {
+				return i.handleError(invalidAccess("can't execute a noaccess string"), val)
+			}
+			err := i.pushFrame(&fileFrame{source: createSourceTokenizer(stringSource, val.String()), file: i.currentFile()})
+			if err != nil {
+				return i.handleError(err, val)
+			}
+			return nil
+		}
+	case PSExecName:
+		return i.executeName(string(val))
+	}
+
+	i.opStack.Push(obj)
+	return nil
+}
+
+// tokenSource supplies tokens one at a time, returning io.EOF when there are none left
+// the Tokenizer reads them lazily from its input, tokenSlice walks tokens that were already read
+type tokenSource interface {
+	Next() (Token, error)
+}
+
+type tokenSlice struct {
+	tokens []Token
+	pos    int
+}
+
+func (s *tokenSlice) Next() (Token, error) {
+	if s.pos >= len(s.tokens) {
+		return Token{}, io.EOF
+	}
+	token := s.tokens[s.pos]
+	s.pos++
+	return token, nil
+}
+
+// executes operation based on token type from list of tokens given as argument
+func (i *Interpreter) Execute(tokens []Token) error {
+	return i.runFrame(&fileFrame{source: &tokenSlice{tokens: tokens}, file: &PSFile{}})
+}
+
+// converts a token to the object it stands for, reading the rest of the procedure from source for {
+// operators become executable names and //name is replaced by its value
+// ok is false when nothing was produced, i.e. an undefined //name whose error handler recovered
+func (i *Interpreter) tokenObject(token Token, source tokenSource) (PSConstant, bool, error) {
+	switch token.Type {
+	// errors without a position of their own, i.e. a missing }, point at the {
+	case TOKEN_BLOCK_START:
+		procedure, err := i.readProcedure(source)
+		return procedure, err == nil, withPosition(err, token.Pos)
+
+	// a } with no { before it
+	case TOKEN_BLOCK_END:
+		return nil, false, withPosition(newPSError("syntaxerror", "unexpected }"), token.Pos)
+
+	case TOKEN_OPERATOR:
+		return PSExecName(token.Value.(string)), true, nil
+
+	case TOKEN_IMMEDIATE:
+		value, found, err := i.immediateLookup(token.Value.(string))
+		return value, found, withPosition(err, token.Pos)
+
+	// made once when it's read, so a string inside a procedure is the same object every time it runs
+	case TOKEN_STRING:
+		return CreateString(token.Value.(string)), true, nil
+
+	default:
+		return token.Value, true, nil
+	}
+}
+
+// reads the next complete object from source, a whole procedure if it starts with {
+// ok is false once source has no more tokens
+func (i *Interpreter) scanObject(source tokenSource) (PSConstant, bool, error) {
+	for {
+		token, err := source.Next()
+		if err == io.EOF {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		obj, ok, err := i.tokenObject(token, source)
+		if err != nil || ok {
+			return obj, ok, err
+		}
+	}
+}
+
+// reads the body of a procedure after its {, up to the matching }
+// tokens are converted to the objects they stand for, nested blocks become nested procedures
+func (i *Interpreter) readProcedure(source tokenSource) (PSArray, error) {
+	items := []PSConstant{}
+	positions := []Position{} // kept alongside items so errors can point at the offending token
+
+	for {
+		currentToken, err := source.Next()
+		if err == io.EOF {
+			return PSArray{}, newPSError("syntaxerror", "unclosed procedure")
+		}
+		if err != nil {
+			return PSArray{}, err
+		}
+
+		// the procedure block is done
+		if currentToken.Type == TOKEN_BLOCK_END {
+			procedure := CreateArray(items)
+			procedure.Executable = true
+			procedure.positions = positions
+
+			// capturing the whole chain of dictionaries in scope for lexical mode
+			if i.lexicalMode {
+				procedure.CapturedDicts = i.captureDicts()
+			}
+			return procedure, nil
+		}
+
+		// operators inside a procedure are stored as executable names and looked up when run
+		item, ok, err := i.tokenObject(currentToken, source)
+		if err != nil {
+			return PSArray{}, err
+		}
+		if ok {
+			items = append(items, item)
+			positions = append(positions, currentToken.Pos)
+		}
+	}
+}
@@ -1,6 +1,7 @@
-package main
+package ps
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -20,7 +21,7 @@ func TestAdd(t *testing.T) {
 		{Type: TOKEN_OPERATOR, Value: "add"},
 	}
 	testInterpreter := executeTest(t, tokens)
-	compareStackTop(t, testInterpreter, 8.0)
+	compareStackTop(t, testInterpreter, 8)
 }
 
 func TestSub(t *testing.T) {
@@ -30,7 +31,7 @@ func TestSub(t *testing.T) {
 		{Type: TOKEN_OPERATOR, Value: "sub"},
 	}
 	testInterpreter := executeTest(t, tokens)
-	compareStackTop(t, testInterpreter, 7.0)
+	compareStackTop(t, testInterpreter, 7)
 }
 
 func TestMul(t *testing.T) {
@@ -40,7 +41,7 @@ func TestMul(t *testing.T) {
 		{Type: TOKEN_OPERATOR, Value: "mul"},
 	}
 	testInterpreter := executeTest(t, tokens)
-	compareStackTop(t, testInterpreter, 20.0)
+	compareStackTop(t, testInterpreter, 20)
 }
 
 func TestDiv(t *testing.T) {
@@ -92,7 +93,7 @@ func TestAbs(t *testing.T) {
 		{Type: TOKEN_OPERATOR, Value: "abs"},
 	}
 	testInterpreter := executeTest(t, tokens)
-	compareStackTop(t, testInterpreter, 5.0)
+	compareStackTop(t, testInterpreter, 5)
 }
 
 func TestNeg(t *testing.T) {
@@ -101,7 +102,7 @@ func TestNeg(t *testing.T) {
 		{Type: TOKEN_OPERATOR, Value: "neg"},
 	}
 	testInterpreter := executeTest(t, tokens)
-	compareStackTop(t, testInterpreter, -5.0)
+	compareStackTop(t, testInterpreter, -5)
 }
 
 func TestSqrt(t *testing.T) {
@@ -150,7 +151,7 @@ func TestComplexArithmetic(t *testing.T) {
 		{Type: TOKEN_OPERATOR, Value: "mul"},
 	}
 	testInterpreter := executeTest(t, tokens)
-	compareStackTop(t, testInterpreter, 16.0)
+	compareStackTop(t, testInterpreter, 16)
 }
 
 // ============================================ stack manipulation tests
@@ -363,7 +364,7 @@ func TestUndefinedOperatorError(t *testing.T) {
 
 // ============================================ building procedure tests
 
-func TestBuildProcedureSimple(t *testing.T) {
+func TestReadProcedureSimple(t *testing.T) {
 	// building: {1 2 add}
 	tokens := []Token{
 		{Type: TOKEN_BLOCK_START},
@@ -374,33 +375,38 @@ func TestBuildProcedureSimple(t *testing.T) {
 	}
 
 	testInterpreter := CreateInterpreter()
-	proc, endPos, err := testInterpreter.buildProcedure(tokens, 0)
+	source := &tokenSlice{tokens: tokens, pos: 1} // just after the {
+	proc, err := testInterpreter.readProcedure(source)
+	endPos := source.pos
 
 	if err != nil {
-		t.Fatalf("buildProcedure failed: %v", err)
+		t.Fatalf("readProcedure failed: %v", err)
 	}
 
 	if endPos != 5 {
 		t.Errorf("Expected endPos 5, got %d", endPos)
 	}
 
-	if len(proc.Body) != 3 {
-		t.Errorf("Expected 3 tokens in procedure body, got %d", len(proc.Body))
+	if len(proc.Items) != 3 {
+		t.Fatalf("Expected 3 items in procedure body, got %d", len(proc.Items))
 	}
 
-	// token check
-	if proc.Body[0].Type != TOKEN_INT || proc.Body[0].Value != 1 {
-		t.Errorf("Expected first token to be INT 1")
+	// item check
+	if proc.Items[0] != 1 {
+		t.Errorf("Expected first item to be INT 1")
+	}
+	if proc.Items[1] != 2 {
+		t.Errorf("Expected second item to be INT 2")
 	}
-	if proc.Body[1].Type != TOKEN_INT || proc.Body[1].Value != 2 {
-		t.Errorf("Expected second token to be INT 2")
+	if proc.Items[2] != PSExecName("add") {
+		t.Errorf("Expected third item to be executable name add")
 	}
-	if proc.Body[2].Type != TOKEN_OPERATOR || proc.Body[2].Value != "add" {
-		t.Errorf("Expected third token to be OPERATOR add")
+	if !proc.Executable {
+		t.Errorf("Expected procedure to be executable")
 	}
 }
 
-func TestBuildProcedureNested(t *testing.T) {
+func TestReadProcedureNested(t *testing.T) {
 	// building: { { 1 2 } 3 }
 	tokens := []Token{
 		{Type: TOKEN_BLOCK_START},
@@ -413,30 +419,33 @@ func TestBuildProcedureNested(t *testing.T) {
 	}
 
 	testInterpreter := CreateInterpreter()
-	proc, endPos, err := testInterpreter.buildProcedure(tokens, 0)
+	source := &tokenSlice{tokens: tokens, pos: 1} // just after the {
+	proc, err := testInterpreter.readProcedure(source)
+	endPos := source.pos
 
 	if err != nil {
-		t.Fatalf("buildProcedure failed: %v", err)
+		t.Fatalf("readProcedure failed: %v", err)
 	}
 
 	if endPos != 7 {
 		t.Errorf("Expected endPos 7, got %d", endPos)
 	}
 
-	if len(proc.Body) != 5 {
-		t.Errorf("Expected 4 tokens in procedure body, got %d", len(proc.Body))
+	if len(proc.Items) != 2 {
+		t.Fatalf("Expected 2 items in procedure body, got %d", len(proc.Items))
 	}
 
 	// checking nested block structure
-	if proc.Body[0].Type != TOKEN_BLOCK_START {
-		t.Errorf("Expected first token to be BLOCK_START")
+	nested, ok := proc.Items[0].(PSArray)
+	if !ok || len(nested.Items) != 2 {
+		t.Errorf("Expected first item to be the nested procedure {1 2}")
 	}
-	if proc.Body[4].Type != TOKEN_INT || proc.Body[4].Value != 3 {
-		t.Errorf("Expected last token to be INT 3")
+	if proc.Items[1] != 3 {
+		t.Errorf("Expected last item to be INT 3")
 	}
 }
 
-func TestBuildProcedureEmpty(t *testing.T) {
+func TestReadProcedureEmpty(t *testing.T) {
 	// building: { }
 	tokens := []Token{
 		{Type: TOKEN_BLOCK_START},
@@ -444,22 +453,24 @@ func TestBuildProcedureEmpty(t *testing.T) {
 	}
 
 	testInterpreter := CreateInterpreter()
-	proc, endPos, err := testInterpreter.buildProcedure(tokens, 0)
+	source := &tokenSlice{tokens: tokens, pos: 1} // just after the {
+	proc, err := testInterpreter.readProcedure(source)
+	endPos := source.pos
 
 	if err != nil {
-		t.Fatalf("buildProcedure failed: %v", err)
+		t.Fatalf("readProcedure failed: %v", err)
 	}
 
 	if endPos != 2 {
 		t.Errorf("Expected endPos 2, got %d", endPos)
 	}
 
-	if len(proc.Body) != 0 {
-		t.Errorf("Expected empty procedure body, got %d tokens", len(proc.Body))
+	if len(proc.Items) != 0 {
+		t.Errorf("Expected empty procedure body, got %d items", len(proc.Items))
 	}
 }
 
-func TestBuildProcedureUnclosed(t *testing.T) {
+func TestReadProcedureUnclosed(t *testing.T) {
 	// building: { 1 2 
 	tokens := []Token{
 		{Type: TOKEN_BLOCK_START},
@@ -469,13 +480,232 @@ func TestBuildProcedureUnclosed(t *testing.T) {
 	}
 
 	testInterpreter := CreateInterpreter()
-	_, _, err := testInterpreter.buildProcedure(tokens, 0)
+	_, err := testInterpreter.readProcedure(&tokenSlice{tokens: tokens, pos: 1})
 
 	if err == nil {
 		t.Error("Expected error for unclosed procedure, got nil")
 	}
 
-	if err.Error() != "unclosed procedure" {
-		t.Errorf("Expected 'unclosed procedure' error, got: %v", err)
+	psErr, ok := err.(*PSError)
+	if !ok || psErr.Name != "syntaxerror" {
+		t.Errorf("Expected syntaxerror for unclosed procedure, got: %v", err)
+	}
+}
+
+func TestUnclosedProcedureCaught(t *testing.T) {
+	// the syntaxerror goes through errordict, so stopped catches it like any other error
+	testInterpreter := runTest(t, "({1 2) cvx stopped")
+	compareStackTop(t, testInterpreter, true)
+}
+
+// ============================================ executable name tests
+
+func TestProcedureAutoExecute(t *testing.T) {
+	// /square {dup mul} def 5 square -> 25
+	tokens := []Token{
+		{Type: TOKEN_NAME, Value: PSName("square")},
+		{Type: TOKEN_BLOCK_START},
+		{Type: TOKEN_OPERATOR, Value: "dup"},
+		{Type: TOKEN_OPERATOR, Value: "mul"},
+		{Type: TOKEN_BLOCK_END},
+		{Type: TOKEN_OPERATOR, Value: "def"},
+		{Type: TOKEN_INT, Value: 5},
+		{Type: TOKEN_OPERATOR, Value: "square"},
+	}
+	testInterpreter := executeTest(t, tokens)
+	compareStackCount(t, testInterpreter, 1)
+	compareStackTop(t, testInterpreter, 25)
+}
+
+func TestLiteralProcedureNotExecuted(t *testing.T) {
+	// /p {1} cvlit def p -> procedure is pushed, not run
+	tokens := []Token{
+		{Type: TOKEN_NAME, Value: PSName("p")},
+		{Type: TOKEN_BLOCK_START},
+		{Type: TOKEN_INT, Value: 1},
+		{Type: TOKEN_BLOCK_END},
+		{Type: TOKEN_OPERATOR, Value: "cvlit"},
+		{Type: TOKEN_OPERATOR, Value: "def"},
+		{Type: TOKEN_OPERATOR, Value: "p"},
+	}
+	testInterpreter := executeTest(t, tokens)
+	compareStackCount(t, testInterpreter, 1)
+
+	top, _ := testInterpreter.opStack.Peek()
+	if _, ok := top.(PSArray); !ok {
+		t.Errorf("Expected PSArray on top of stack, got %T", top)
+	}
+}
+
+func TestExecutableNameLookup(t *testing.T) {
+	// /x 7 def /x cvx exec -> 7
+	tokens := []Token{
+		{Type: TOKEN_NAME, Value: PSName("x")},
+		{Type: TOKEN_INT, Value: 7},
+		{Type: TOKEN_OPERATOR, Value: "def"},
+		{Type: TOKEN_NAME, Value: PSName("x")},
+		{Type: TOKEN_OPERATOR, Value: "cvx"},
+		{Type: TOKEN_OPERATOR, Value: "exec"},
+	}
+	testInterpreter := executeTest(t, tokens)
+	compareStackTop(t, testInterpreter, 7)
+}
+
+func TestExecLiteralPushesBack(t *testing.T) {
+	tokens := []Token{
+		{Type: TOKEN_INT, Value: 3},
+		{Type: TOKEN_OPERATOR, Value: "exec"},
+	}
+	testInterpreter := executeTest(t, tokens)
+	compareStackCount(t, testInterpreter, 1)
+	compareStackTop(t, testInterpreter, 3)
+}
+
+func TestLexicalProcedureSeesOperators(t *testing.T) {
+	// operators live in systemdict, which has to stay visible inside lexical procedures
+	testInterpreter := New(Options{Lexical: true})
+	err := testInterpreter.Run("/f {1 2 add} def f")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	compareStackTop(t, testInterpreter, 3)
+}
+
+func TestImmediateName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{"operator runs at top level", "3 4 //add", 7},
+		{"value captured when procedure is built", "/x 1 def /f {//x} def /x 2 def f", 1},
+		{"operator bound inside procedure", "/f {//add} def /add {mul} def 3 4 f", 7},
+		{"special character names", "/move-to {10 add} def 5 move-to", 15},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestImmediateNameProcedurePushed(t *testing.T) {
+	// a procedure found through //name is pushed, not run
+	testInterpreter := runTest(t, "/f {1 2 add} def //f")
+	top, _ := testInterpreter.opStack.Peek()
+	if procedure, ok := top.(PSArray); !ok || !procedure.Executable {
+		t.Errorf("Expected an executable PSArray on top of stack, got %v", top)
 	}
 }
+
+func TestImmediateNameUndefined(t *testing.T) {
+	testInterpreter := CreateInterpreter()
+	err := testInterpreter.Run("/f {//nope} def")
+
+	psErr, ok := err.(*PSError)
+	if !ok || psErr.Name != "undefined" {
+		t.Errorf("Expected undefined, got %v", err)
+	}
+}
+
+func TestScopingExamples(t *testing.T) {
+	// the same program run with both scoping modes
+	// dynamic looks names up in the dict stack at the time of the call, lexical in the one the procedure was written in
+	tests := []struct {
+		name    string
+		input   string
+		dynamic any
+		lexical any
+	}{
+		{
+			"global shadowed by caller",
+			"/x 1 def /show {x} def 10 dict begin /x 2 def show end",
+			2, 1,
+		},
+		{
+			"closure made inside begin sees globals and the begin dict",
+			"/x 1 def 10 dict begin /y 2 def {x y add} end /g exch def {g} stopped {(error)} if",
+			"error", 3,
+		},
+		{
+			"built-in variable inside closure",
+			"10 dict begin {ARGUMENTS length} end exec",
+			0, 0,
+		},
+		{
+			"nested closures",
+			"/x 1 def 10 dict begin /x 2 def {{x}} end /mk exch def /x 3 def mk exec",
+			3, 2,
+		},
+		{
+			"def inside closure",
+			"/x 0 def 10 dict begin {/x 5 def} end /setx exch def 10 dict begin setx x end",
+			5, 0,
+		},
+		{
+			"definitions made after the closure are seen",
+			"/f {y} def /y 7 def f",
+			7, 7,
+		},
+		{
+			"recursion",
+			"/fact {dup 1 le {pop 1} {dup 1 sub fact mul} ifelse} def 5 fact",
+			120, 120,
+		},
+		{
+			"procedure argument",
+			"/x 1 def /apply {exec} def 10 dict begin /x 2 def {x} apply end",
+			2, 2,
+		},
+	}
+
+	for _, test := range tests {
+		for _, lexical := range []bool{false, true} {
+			expected := test.dynamic
+			if lexical {
+				expected = test.lexical
+			}
+			t.Run(fmt.Sprintf("%s lexical=%v", test.name, lexical), func(t *testing.T) {
+				testInterpreter := New(Options{Lexical: lexical})
+				err := testInterpreter.Run(test.input)
+				if err != nil {
+					t.Fatalf("Run failed: %v", err)
+				}
+				compareStackTop(t, testInterpreter, expected)
+			})
+		}
+	}
+}
+
+func TestLexicalBeginInsideClosure(t *testing.T) {
+	// begin and end inside a closure leave the dict stack it captured alone
+	testInterpreter := New(Options{Lexical: true})
+	err := testInterpreter.Run("/x 1 def /f {10 dict begin /x 2 def end 10 dict begin x end} def f f")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	compareStackTop(t, testInterpreter, 1)
+	compareStackCount(t, testInterpreter, 2)
+}
+
+func TestSetLexical(t *testing.T) {
+	// an embedding program can switch scoping between runs
+	testInterpreter := New(Options{})
+	testInterpreter.SetLexical(true)
+	if !testInterpreter.Lexical() {
+		t.Fatal("Expected Lexical to report true after SetLexical(true)")
+	}
+
+	err := testInterpreter.Run("/x 1 def /show {x} def")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	testInterpreter.SetLexical(false)
+	err = testInterpreter.Run("10 dict begin /x 2 def show end")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	compareStackTop(t, testInterpreter, 1)
+}
@@ -0,0 +1,100 @@
+package ps
+
+import "fmt"
+
+// defining the structure of a stack
+type Stack struct {
+	items     []PSConstant // items in stack
+	itemCount int          // number of items in stack
+	low       int          // fewest items the stack has held since the last checkpoint
+	removed   []PSConstant // items that were below low when they were popped, most recently popped last
+}
+
+// constructor, creates instance of stack
+// *Stack: pointer to a stack
+func CreateStack() *Stack {
+	return &Stack{
+		items: make([]PSConstant, 0),
+	}
+}
+
+// adds a PSConstant item to the top of the stack and increases the item count
+func (s *Stack) Push(item PSConstant) {
+	s.items = append(s.items, item)
+	s.itemCount++
+}
+
+// removes and returns PSConstant item from the top of the stack and decreases the item count
+func (s *Stack) Pop() (PSConstant, error) {
+	if len(s.items) <= 0 {
+		return nil, fmt.Errorf("no items in stack")
+	}
+
+	// hanging on to the item removed to be returned
+	item := s.items[len(s.items)-1]
+
+	// slicing at item index to shave off removed item
+	s.items = s.items[:len(s.items)-1]
+	s.itemCount--
+
+	// remembering items that were there at the checkpoint so restore can put them back
+	if len(s.items) < s.low {
+		s.low = len(s.items)
+		s.removed = append(s.removed, item)
+	}
+
+	return item, nil
+}
+
+// starts keeping track of the items popped so restore can put the stack back the way it is now
+func (s *Stack) checkpoint() {
+	s.low = len(s.items)
+	s.removed = s.removed[:0]
+}
+
+// puts the stack back the way it was at the last checkpoint, undoing the pushes and pops since
+func (s *Stack) restore() {
+	s.items = s.items[:s.low]
+	for index := len(s.removed) - 1; index >= 0; index-- {
+		s.items = append(s.items, s.removed[index])
+	}
+	s.itemCount = len(s.items)
+	s.checkpoint()
+}
+
+// returns PSConstant at the top of the stack without removing it
+func (s *Stack) Peek() (PSConstant, error) {
+	if len(s.items) <= 0 {
+		return nil, fmt.Errorf("stack underflow, no items in stack")
+	}
+	return s.items[len(s.items)-1], nil
+}
+
+// returns PSConstant n places below the top of the stack without removing it (0 is the top)
+func (s *Stack) Index(n int) (PSConstant, error) {
+	if n < 0 || n >= len(s.items) {
+		return nil, fmt.Errorf("stack underflow, not enough items in stack")
+	}
+	return s.items[len(s.items)-1-n], nil
+}
+
+// returns the number of items above the topmost mark
+func (s *Stack) CountToMark() (int, error) {
+	for index := len(s.items) - 1; index >= 0; index-- {
+		if _, ok := s.items[index].(PSMark); ok {
+			return len(s.items) - 1 - index, nil
+		}
+	}
+	return 0, fmt.Errorf("no mark in stack")
+}
+
+// returns boolean value indicating whether stack is empty
+// note: returns true if stack is empty
+func (s *Stack) IsEmpty() bool {
+	return len(s.items) <= 0
+}
+
+// helper function to return stack count without giving direct access to stack top
+func (s *Stack) StackCount() int {
+	return s.itemCount
+}
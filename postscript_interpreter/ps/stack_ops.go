@@ -0,0 +1,200 @@
+package ps
+
+import "fmt"
+
+// =================================== stack operations
+
+// opDup duplicates top of stack and pushes it to top of stack
+func opDup(i *Interpreter) error {
+	topStack, err := i.opStack.Peek()
+	if err != nil {
+		return stackUnderflow()
+	}
+	i.opStack.Push(topStack)
+
+	return nil
+}
+
+// opPop pops the top of the stack
+func opPop(i *Interpreter) error {
+	if _, err := i.opStack.Pop(); err != nil {
+		return stackUnderflow()
+	}
+
+	return nil
+}
+
+// opExch swaps the 2 top-most elements of the stack
+func opExch(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+	b, _ := i.opStack.Pop()
+	a, _ := i.opStack.Pop()
+
+	i.opStack.Push(b)
+	i.opStack.Push(a)
+
+	return nil
+}
+
+// opClear clears the stack
+func opClear(i *Interpreter) error {
+	for i.opStack.StackCount() > 0 {
+		i.opStack.Pop()
+	}
+
+	return nil
+}
+
+// opCount pushes the number of elements in stack onto the stack
+func opCount(i *Interpreter) error {
+	count := i.opStack.StackCount()
+	i.opStack.Push(count)
+
+	return nil
+}
+
+// opMark pushes a mark, [ and << are the same operator under other names
+func opMark(i *Interpreter) error {
+	i.opStack.Push(PSMark{})
+	return nil
+}
+
+// opCountToMark pushes the number of elements above the topmost mark
+func opCountToMark(i *Interpreter) error {
+	count, err := i.opStack.CountToMark()
+	if err != nil {
+		return newPSError("unmatchedmark", "no mark in stack")
+	}
+
+	i.opStack.Push(count)
+	return nil
+}
+
+// opClearToMark pops everything down to and including the topmost mark
+func opClearToMark(i *Interpreter) error {
+	count, err := i.opStack.CountToMark()
+	if err != nil {
+		return newPSError("unmatchedmark", "no mark in stack")
+	}
+
+	for count >= 0 {
+		i.opStack.Pop()
+		count--
+	}
+	return nil
+}
+
+// opIndex pushes a copy of the nth element below the top (0 index dup)
+func opIndex(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	n, ok := val.(int)
+	if !ok {
+		return typeCheck("index requires an integer")
+	}
+	if n < 0 {
+		return rangeCheck("index cannot be negative")
+	}
+
+	item, err := i.opStack.Index(n)
+	if err != nil {
+		return stackUnderflow()
+	}
+	i.opStack.Push(item)
+	return nil
+}
+
+// opCopy duplicates the top n elements of the stack
+func opCopy(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	// copying one string into another is handled in string_ops.go
+	if top, _ := i.opStack.Peek(); isString(top) {
+		if i.opStack.StackCount() < 2 {
+			return stackUnderflow()
+		}
+		return opStringCopy(i)
+	}
+
+	val, _ := i.opStack.Pop()
+
+	n, ok := val.(int)
+	if !ok {
+		return typeCheck("copy requires an integer")
+	}
+	if n < 0 {
+		return rangeCheck("copy count cannot be negative")
+	}
+	if n > i.opStack.StackCount() {
+		return stackUnderflow()
+	}
+
+	// the element n-1 below the top is always the next one to copy as the stack grows
+	for copied := 0; copied < n; copied++ {
+		item, _ := i.opStack.Index(n - 1)
+		i.opStack.Push(item)
+	}
+	return nil
+}
+
+// opRoll rotates the top n elements of the stack j positions
+// positive j moves elements up towards the top, negative j moves them down
+func opRoll(i *Interpreter) error {
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+	jVal, _ := i.opStack.Pop()
+	nVal, _ := i.opStack.Pop()
+
+	n, okN := nVal.(int)
+	j, okJ := jVal.(int)
+	if !okN || !okJ {
+		return typeCheck("roll requires two integers")
+	}
+	if n < 0 {
+		return rangeCheck("roll count cannot be negative")
+	}
+	if n > i.opStack.StackCount() {
+		return stackUnderflow()
+	}
+	if n == 0 {
+		return nil
+	}
+
+	// taking the top n elements off, bottom-most first
+	items := make([]PSConstant, n)
+	for index := n - 1; index >= 0; index-- {
+		items[index], _ = i.opStack.Pop()
+	}
+
+	// normalizing j so it's a positive shift smaller than n
+	shift := ((j % n) + n) % n
+	for index := range items {
+		i.opStack.Push(items[(index-shift+n)%n])
+	}
+	return nil
+}
+
+// opPstack prints the whole stack top first in == form without changing it
+func opPstack(i *Interpreter) error {
+	for depth := 0; depth < i.opStack.StackCount(); depth++ {
+		item, _ := i.opStack.Index(depth)
+		fmt.Fprintln(i.output(), formatObject(item))
+	}
+	return nil
+}
+
+// opStack prints the whole stack top first in = form without changing it
+func opStack(i *Interpreter) error {
+	for depth := 0; depth < i.opStack.StackCount(); depth++ {
+		item, _ := i.opStack.Index(depth)
+		fmt.Fprintln(i.output(), formatText(item))
+	}
+	return nil
+}
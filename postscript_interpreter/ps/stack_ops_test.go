@@ -1,4 +1,4 @@
-package main
+package ps
 
 import (
 	"testing"
@@ -28,7 +28,7 @@ func TestOpDup(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			testInterpreter := CreateInterpreter()
-			testInterpreter.opStack.Push(test.value)
+			testInterpreter.opStack.Push(testValue(test.value))
 
 			opDup(testInterpreter)
 
@@ -39,7 +39,7 @@ func TestOpDup(t *testing.T) {
 			first, _ := testInterpreter.opStack.Pop()
 			second, _ := testInterpreter.opStack.Pop()
 
-			if first != second || first != test.value {
+			if plainValue(first) != plainValue(second) || plainValue(first) != test.value {
 				t.Errorf("dup failed for %v", test.value)
 			}
 		})
@@ -97,7 +97,7 @@ func TestOpExch(t *testing.T) {
 // ensuring exch works on different types of data
 func TestOpExchWithDifferentTypes(t *testing.T) {
 	testInterpreter := CreateInterpreter()
-	testInterpreter.opStack.Push("hello")
+	testInterpreter.opStack.Push(CreateString("hello"))
 	testInterpreter.opStack.Push(42)
 
 	err := opExch(testInterpreter)
@@ -108,7 +108,7 @@ func TestOpExchWithDifferentTypes(t *testing.T) {
 	first, _ := testInterpreter.opStack.Pop()
 	second, _ := testInterpreter.opStack.Pop()
 
-	if first != "hello" || second != 42 {
+	if plainValue(first) != "hello" || second != 42 {
 		t.Errorf("exch failed: got first=%v, second=%v", first, second)
 	}
 }
@@ -207,3 +207,187 @@ func TestStackOpsChaining(t *testing.T) {
 		t.Errorf("expected first to be 3, got %v", first)
 	}
 }
+
+// mark operations =============================================
+
+func TestOpMark(t *testing.T) {
+	testInterpreter := CreateInterpreter()
+
+	err := opMark(testInterpreter)
+	if err != nil {
+		t.Fatalf("unexpected mark error: %v", err)
+	}
+	compareStackTop(t, testInterpreter, PSMark{})
+}
+
+func TestOpCountToMark(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{"empty above mark", "mark", 0},
+		{"items above mark", "mark 1 2 3", 3},
+		{"topmost mark is used", "mark 1 mark 2 3", 2},
+		{"items below mark ignored", "1 2 mark 3", 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input+" counttomark")
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestOpClearToMark(t *testing.T) {
+	testInterpreter := runTest(t, "1 mark 2 3 cleartomark")
+	compareStackCount(t, testInterpreter, 1)
+	compareStackTop(t, testInterpreter, 1)
+}
+
+func TestMarkOpsUnmatched(t *testing.T) {
+	inputs := []string{"1 2 counttomark", "1 2 cleartomark", "1 2 ]", "/a 1 >>"}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			testInterpreter := CreateInterpreter()
+			err := testInterpreter.Run(input)
+
+			psErr, ok := err.(*PSError)
+			if !ok || psErr.Name != "unmatchedmark" {
+				t.Errorf("expected unmatchedmark, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVariadicWithMark(t *testing.T) {
+	// collecting a variable number of arguments into an array with counttomark
+	testInterpreter := runTest(t, "mark 1 2 3 4 counttomark array astore exch pop length")
+	compareStackCount(t, testInterpreter, 1)
+	compareStackTop(t, testInterpreter, 4)
+}
+
+// index, copy and roll =============================================
+
+func TestOpIndex(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{"top of stack", "1 2 3 0 index", 3},
+		{"middle of stack", "1 2 3 1 index", 2},
+		{"bottom of stack", "1 2 3 2 index", 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackCount(t, testInterpreter, 4)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestOpCopy(t *testing.T) {
+	testInterpreter := runTest(t, "1 2 3 2 copy")
+
+	expected := []PSConstant{1, 2, 3, 2, 3}
+	stack := testInterpreter.Stack()
+	if len(stack) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, stack)
+	}
+	for index := range expected {
+		if plainValue(stack[index]) != expected[index] {
+			t.Errorf("expected %v, got %v", expected, stack)
+		}
+	}
+}
+
+func TestOpRoll(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []PSConstant
+	}{
+		{"roll up", "1 2 3 3 1 roll", []PSConstant{3, 1, 2}},
+		{"roll down", "1 2 3 3 -1 roll", []PSConstant{2, 3, 1}},
+		{"roll part of stack", "1 2 3 4 2 1 roll", []PSConstant{1, 2, 4, 3}},
+		{"roll more than n", "1 2 3 3 4 roll", []PSConstant{3, 1, 2}},
+		{"roll zero elements", "1 2 0 5 roll", []PSConstant{1, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			stack := testInterpreter.Stack()
+			if len(stack) != len(test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, stack)
+			}
+			for index := range test.expected {
+				if plainValue(stack[index]) != test.expected[index] {
+					t.Errorf("expected %v, got %v", test.expected, stack)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestStackOpErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"dup", "stackunderflow"},
+		{"pop", "stackunderflow"},
+		{"1 exch", "stackunderflow"},
+		{"1 2 5 index", "stackunderflow"},
+		{"1 -1 index", "rangecheck"},
+		{"1 2 3 copy", "stackunderflow"},
+		{"1 -1 copy", "rangecheck"},
+		{"1 2 5 1 roll", "stackunderflow"},
+		{"1 2 -1 1 roll", "rangecheck"},
+		{"1 (a) index", "typecheck"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			testInterpreter := CreateInterpreter()
+			err := testInterpreter.Run(test.input)
+
+			psErr, ok := err.(*PSError)
+			if !ok || psErr.Name != test.expected {
+				t.Errorf("expected %s, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestOpPstack(t *testing.T) {
+	testInterpreter := runTest(t, "1 (two) /three")
+
+	output := captureOutput(func() {
+		opPstack(testInterpreter)
+	})
+
+	if output != "/three\n(two)\n1\n" {
+		t.Errorf("unexpected pstack output: %q", output)
+	}
+	compareStackCount(t, testInterpreter, 3)
+}
+
+func TestOpStack(t *testing.T) {
+	testInterpreter := runTest(t, "1 (two) /three")
+
+	output := captureOutput(func() {
+		opStack(testInterpreter)
+	})
+
+	if output != "three\ntwo\n1\n" {
+		t.Errorf("unexpected stack output: %q", output)
+	}
+	compareStackCount(t, testInterpreter, 3)
+}
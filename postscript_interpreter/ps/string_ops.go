@@ -0,0 +1,303 @@
+package ps
+
+import "bytes"
+
+// ======================================== string operations
+
+// reports whether obj is a string
+func isString(obj PSConstant) bool {
+	_, ok := obj.(PSString)
+	return ok
+}
+
+// opLength returns the length of a string/dictionary/array
+func opLength(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+	if err := checkRead(val, "length"); err != nil {
+		return err
+	}
+
+	// try as array
+	if arr, ok := val.(PSArray); ok {
+		i.opStack.Push(len(arr.Items))
+		return nil
+	}
+
+	// try as string
+	if str, ok := val.(PSString); ok {
+		result := len(str.Bytes)
+		i.opStack.Push(result)
+		return nil
+	}
+
+	// try as dict
+	if dict, ok := val.(*PSDict); ok {
+		result := len(dict.items)
+		i.opStack.Push(result)
+		return nil
+	}
+
+	return typeCheck("length requires string, array or dictionary, got: %T", val)
+}
+
+// opString creates a string of n bytes, all zero
+func opString(i *Interpreter) error {
+	if i.opStack.StackCount() < 1 {
+		return stackUnderflow()
+	}
+	val, _ := i.opStack.Pop()
+
+	length, ok := val.(int)
+	if !ok {
+		return typeCheck("string requires an integer length")
+	}
+	if length < 0 {
+		return rangeCheck("string length cannot be negative")
+	}
+
+	i.opStack.Push(PSString{Bytes: make([]byte, length)})
+	return nil
+}
+
+// opGet gets returns the ASCII value of the character at an index
+func opGet(i *Interpreter) error {
+
+	if i.opStack.StackCount() < 2 {
+		return stackUnderflow()
+	}
+
+	// arrays are handled in array_ops.go, dictionaries in dictionary_ops.go
+	composite, _ := i.opStack.Index(1)
+	if isArray(composite) {
+		return opArrayGet(i)
+	}
+	if isDict(composite) {
+		return dOpGet(i)
+	}
+
+	indexVal, _ := i.opStack.Pop() // desired index
+	strVal, _ := i.opStack.Pop()   // string to be indexed
+
+	// converting to usable types
+	index, okIndex := indexVal.(int)
+	str, okStr := strVal.(PSString)
+	if !okIndex || !okStr {
+		return typeCheck("get requires a string and an integer index")
+	}
+	if err := checkRead(str, "get"); err != nil {
+		return err
+	}
+
+	if index >= len(str.Bytes) || index < 0 {
+		return rangeCheck("out of bounds index")
+	}
+	result := str.Bytes[index]
+	i.opStack.Push(int(result))
+
+	return nil
+}
+
+// opStringPut replaces the character at an index with the one whose code is given
+// the string is changed in place so every reference to it sees the new character
+func opStringPut(i *Interpreter) error {
+	value, _ := i.opStack.Pop()
+	indexVal, _ := i.opStack.Pop()
+	strVal, _ := i.opStack.Pop()
+
+	index, okIndex := indexVal.(int)
+	char, okChar := value.(int)
+	if !okIndex || !okChar {
+		return typeCheck("put requires a string, an integer index and an integer character code")
+	}
+	str := strVal.(PSString)
+	if err := checkWrite(str, "put"); err != nil {
+		return err
+	}
+
+	if index < 0 || index >= len(str.Bytes) {
+		return rangeCheck("out of bounds index")
+	}
+	if char < 0 || char > 255 {
+		return rangeCheck("character code %d is not between 0 and 255", char)
+	}
+
+	str.Bytes[index] = byte(char)
+	return nil
+}
+
+// opGetInterval returns substring of given string from index to index + count
+// the substring shares storage with the original, so changes to either are seen by both
+func opGetInterval(i *Interpreter) error {
+
+	if i.opStack.StackCount() < 3 {
+		return stackUnderflow()
+	}
+
+	// arrays are handled in array_ops.go
+	if composite, _ := i.opStack.Index(2); isArray(composite) {
+		return opArrayGetInterval(i)
+	}
+
+	countVal, _ := i.opStack.Pop() // count
+	indexVal, _ := i.opStack.Pop() // starting index
+	strVal, _ := i.opStack.Pop()   // string
+
+	// conversions
+	count, okCount := countVal.(int)
+	index, okIndex := indexVal.(int)
+	str, okStr := strVal.(PSString)
+	if !okCount || !okIndex || !okStr {
+		return typeCheck("getinterval requires a string and two integers")
+	}
+	if err := checkRead(str, "getinterval"); err != nil {
+		return err
+	}
+
+	if index > len(str.Bytes) || index < 0 {
+		return rangeCheck("out of bounds index")
+	}
+	if count < 0 {
+		return rangeCheck("count cannot be negative number")
+	}
+	if index+count > len(str.Bytes) {
+		return rangeCheck("substring goes beyond original string length")
+	}
+
+	// slicing keeps the same backing storage, so the substring aliases the original
+	i.opStack.Push(str.substring(index, index+count))
+	return nil
+}
+
+// opPutInterval copies a string into another one starting at an index
+// the destination is changed in place and has to be long enough to hold it
+func opPutInterval(i *Interpreter) error {
+
+	if i.opStack.StackCount() < 3 {
+		return stackUnderflow()
+	}
+
+	// arrays are handled in array_ops.go
+	if composite, _ := i.opStack.Index(2); isArray(composite) {
+		return opArrayPutInterval(i)
+	}
+
+	s2, _ := i.opStack.Pop()
+	ind, _ := i.opStack.Pop()
+	s1, _ := i.opStack.Pop()
+
+	index, okIndex := ind.(int)
+	str1, okStr1 := s1.(PSString)
+	str2, okStr2 := s2.(PSString)
+	if !okIndex || !okStr1 || !okStr2 {
+		return typeCheck("putinterval requires two strings and an integer index")
+	}
+	if err := checkWrite(str1, "putinterval"); err != nil {
+		return err
+	}
+	if err := checkRead(str2, "putinterval"); err != nil {
+		return err
+	}
+	if index < 0 || index+len(str2.Bytes) > len(str1.Bytes) {
+		return rangeCheck("interval goes beyond string length")
+	}
+
+	copy(str1.Bytes[index:], str2.Bytes)
+	return nil
+}
+
+// opStringCopy copies the first string into the start of the second
+// pushes the part of the second string that was written to
+func opStringCopy(i *Interpreter) error {
+	destVal, _ := i.opStack.Pop()
+	srcVal, _ := i.opStack.Pop()
+
+	src, ok := srcVal.(PSString)
+	if !ok {
+		return typeCheck("copy requires two strings")
+	}
+	dest := destVal.(PSString)
+	if err := checkRead(src, "copy"); err != nil {
+		return err
+	}
+	if err := checkWrite(dest, "copy"); err != nil {
+		return err
+	}
+
+	if len(src.Bytes) > len(dest.Bytes) {
+		return rangeCheck("string of length %d can't hold %d characters", len(dest.Bytes), len(src.Bytes))
+	}
+
+	copy(dest.Bytes, src.Bytes)
+	i.opStack.Push(dest.substring(0, len(src.Bytes)))
+	return nil
+}
+
+// opSearch looks for the first occurrence of seek in a string
+// string seek search → post match pre true | string false
+// post, match and pre are substrings sharing storage with the string
+func opSearch(i *Interpreter) error {
+	str, seek, err := popSearchOperands(i, "search")
+	if err != nil {
+		return err
+	}
+
+	index := bytes.Index(str.Bytes, seek.Bytes)
+	if index < 0 {
+		i.opStack.Push(str)
+		i.opStack.Push(false)
+		return nil
+	}
+
+	end := index + len(seek.Bytes)
+	i.opStack.Push(str.substring(end, len(str.Bytes)))
+	i.opStack.Push(str.substring(index, end))
+	i.opStack.Push(str.substring(0, index))
+	i.opStack.Push(true)
+	return nil
+}
+
+// opAnchorSearch checks whether a string starts with seek
+// string seek anchorsearch → post match true | string false
+func opAnchorSearch(i *Interpreter) error {
+	str, seek, err := popSearchOperands(i, "anchorsearch")
+	if err != nil {
+		return err
+	}
+
+	if !bytes.HasPrefix(str.Bytes, seek.Bytes) {
+		i.opStack.Push(str)
+		i.opStack.Push(false)
+		return nil
+	}
+
+	end := len(seek.Bytes)
+	i.opStack.Push(str.substring(end, len(str.Bytes)))
+	i.opStack.Push(str.substring(0, end))
+	i.opStack.Push(true)
+	return nil
+}
+
+// pops the string and the string to look for used by search and anchorsearch
+func popSearchOperands(i *Interpreter, name string) (PSString, PSString, error) {
+	if i.opStack.StackCount() < 2 {
+		return PSString{}, PSString{}, stackUnderflow()
+	}
+	seekVal, _ := i.opStack.Pop()
+	strVal, _ := i.opStack.Pop()
+
+	str, okStr := strVal.(PSString)
+	seek, okSeek := seekVal.(PSString)
+	if !okStr || !okSeek {
+		return PSString{}, PSString{}, typeCheck("%s requires two strings", name)
+	}
+	if err := checkRead(str, name); err != nil {
+		return PSString{}, PSString{}, err
+	}
+	if err := checkRead(seek, name); err != nil {
+		return PSString{}, PSString{}, err
+	}
+	return str, seek, nil
+}
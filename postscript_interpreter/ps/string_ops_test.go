@@ -0,0 +1,315 @@
+package ps
+
+import (
+	"testing"
+)
+
+/*
+ -----------------------------------------------------------------------------
+	Note: Parts of these tests were drafted with the use of Generative AI.
+	All test content and logic has been reviewed and verified manually.
+ -----------------------------------------------------------------------------
+*/
+
+func TestOpLengthString(t *testing.T) {
+	testInterpreter := CreateInterpreter()
+	testInterpreter.opStack.Push(CreateString("hello"))
+	
+	err := opLength(testInterpreter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	
+	compareStackTop(t, testInterpreter, 5)
+}
+
+func TestOpStrGet(t *testing.T) {
+	i := CreateInterpreter()
+
+	testIndex := 0
+	testString := "hello"
+
+	i.opStack.Push(CreateString(testString))
+	i.opStack.Push(testIndex)
+
+	// getting char value at index
+	err := opGet(i)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	
+	expected := int(testString[testIndex])
+	compareStackTop(t, i, expected)
+}
+
+func TestOpStrGetLastChar(t *testing.T) {
+	// test getting last character
+	i := CreateInterpreter()
+
+	testString := "hello"
+	i.opStack.Push(CreateString(testString))
+
+	i.opStack.Push(4) // last index
+	err := opGet(i)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := int('o') // 111
+	compareStackTop(t, i, expected)
+}
+
+func TestOpStrGetInterval(t *testing.T) {
+	i := CreateInterpreter()
+
+	testIndex := 1
+	testString := "hello"
+	testCount := 3
+
+	i.opStack.Push(CreateString(testString))
+	i.opStack.Push(testIndex)
+	i.opStack.Push(testCount)
+
+	// creating substring
+	err := opGetInterval(i)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := string("ell")
+	compareStackTop(t, i, expected)
+}
+
+func TestOpStrGetIntervalFullString(t *testing.T) {
+	// test getting entire string
+	i := CreateInterpreter()
+
+	testString := "world"
+	i.opStack.Push(CreateString(testString))
+
+	i.opStack.Push(0) // start at beginning
+	i.opStack.Push(5) // get all 5 chars
+
+	err := opGetInterval(i)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compareStackTop(t, i, "world")
+}
+
+func TestOpStrGetIntervalSingleChar(t *testing.T) {
+	// test getting single character substring
+	i := CreateInterpreter()
+
+	i.opStack.Push(CreateString("hello"))
+	i.opStack.Push(2) // index 2
+	i.opStack.Push(1) // count 1
+
+	err := opGetInterval(i)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compareStackTop(t, i, "l")
+}
+
+func TestOpPutInterval(t *testing.T) {
+	i := CreateInterpreter()
+
+	testStr1 := CreateString("hello")
+	testStr2 := CreateString("MOO")
+	testCount := 1
+
+	i.opStack.Push(testStr1)
+	i.opStack.Push(testCount)
+	i.opStack.Push(testStr2)
+
+	err := opPutInterval(i)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the original string is changed in place and nothing is pushed
+	compareStackCount(t, i, 0)
+	expected := string("hMOOo")
+	if testStr1.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, testStr1)
+	}
+}
+
+func TestOpPutIntervalAtStart(t *testing.T) {
+	// test replacing at start of string
+	i := CreateInterpreter()
+
+	str := CreateString("hello")
+	i.opStack.Push(str)
+	i.opStack.Push(0) // start at beginning
+	i.opStack.Push(CreateString("XY"))
+
+	err := opPutInterval(i)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if str.String() != "XYllo" {
+		t.Errorf("Expected XYllo, got %s", str)
+	}
+}
+
+func TestOpPutIntervalAtEnd(t *testing.T) {
+	// test replacing at end of string
+	i := CreateInterpreter()
+
+	str := CreateString("hello")
+	i.opStack.Push(str)
+	i.opStack.Push(3) // index 3
+	i.opStack.Push(CreateString("AB"))
+
+	err := opPutInterval(i)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if str.String() != "helAB" {
+		t.Errorf("Expected helAB, got %s", str)
+	}
+}
+
+func TestOpPutIntervalSingleChar(t *testing.T) {
+	// test replacing single character
+	i := CreateInterpreter()
+
+	str := CreateString("hello")
+	i.opStack.Push(str)
+	i.opStack.Push(2) // index 2
+	i.opStack.Push(CreateString("X"))
+
+	err := opPutInterval(i)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if str.String() != "heXlo" {
+		t.Errorf("Expected heXlo, got %s", str)
+	}
+}
+
+func TestOpPutIntervalLongerReplacement(t *testing.T) {
+	// test when replacement is longer than remaining string
+	// strings don't grow, so this is an error
+	i := CreateInterpreter()
+
+	i.opStack.Push(CreateString("hi"))
+	i.opStack.Push(1) // index 1
+	i.opStack.Push(CreateString("WORLD"))
+
+	err := opPutInterval(i)
+	psErr, ok := err.(*PSError)
+	if !ok || psErr.Name != "rangecheck" {
+		t.Errorf("Expected rangecheck, got %v", err)
+	}
+}
+
+func TestStringSharing(t *testing.T) {
+	// strings are mutable objects, every reference to one sees changes made through another
+	tests := []struct {
+		name     string
+		input    string
+		expected any
+	}{
+		{"substring aliases parent", "/s (hello) def s 1 3 getinterval 0 88 put s", "hXllo"},
+		{"parent change seen by substring", "/s (hello) def s 1 3 getinterval s 1 89 put", "Yll"},
+		{"fill a buffer", "/buf 5 string def buf 0 (ab) putinterval buf 2 (cde) putinterval buf", "abcde"},
+		{"dup shares", "(abc) dup 0 65 put", "Abc"},
+		{"literal in procedure is one object", "/f {(aa)} def f 0 66 put f", "Ba"},
+		{"rest of string from token", "/s (1 xyz) def s token pop pop 0 65 put s", "1 Ayz"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testInterpreter := runTest(t, test.input)
+			compareStackTop(t, testInterpreter, test.expected)
+		})
+	}
+}
+
+func TestOpString(t *testing.T) {
+	testInterpreter := runTest(t, "3 string dup length exch 0 get")
+	compareStackTop(t, testInterpreter, 0)
+	testInterpreter.opStack.Pop()
+	compareStackTop(t, testInterpreter, 3)
+}
+
+func TestOpStringPut(t *testing.T) {
+	testInterpreter := runTest(t, "/s (abc) def s 2 90 put s")
+	compareStackTop(t, testInterpreter, "abZ")
+}
+
+func TestOpSearch(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []PSConstant
+	}{
+		{"found", "(abcbd) (b) search", []PSConstant{"cbd", "b", "a", true}},
+		{"found at start", "(abc) (ab) search", []PSConstant{"c", "ab", "", true}},
+		{"not found", "(abc) (x) search", []PSConstant{"abc", false}},
+		{"anchored", "(abc) (ab) anchorsearch", []PSConstant{"c", "ab", true}},
+		{"not anchored", "(abc) (b) anchorsearch", []PSConstant{"abc", false}},
+		{"empty seek", "(abc) () anchorsearch", []PSConstant{"abc", "", true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stack := runTest(t, test.input).Stack()
+			if len(stack) != len(test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, stack)
+			}
+			for index := range test.expected {
+				if plainValue(stack[index]) != test.expected[index] {
+					t.Errorf("expected %v, got %v", test.expected, stack)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestOpSearchSharesStorage(t *testing.T) {
+	testInterpreter := runTest(t, "/s (a-b) def s (-) search pop 0 88 put s")
+	compareStackTop(t, testInterpreter, "X-b")
+}
+
+func TestOpStringCopy(t *testing.T) {
+	testInterpreter := runTest(t, "/d (xxxxx) def (ab) d copy d")
+	compareStackTop(t, testInterpreter, "abxxx")
+	testInterpreter.opStack.Pop()
+	compareStackTop(t, testInterpreter, "ab")
+}
+
+func TestStringOperatorErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"-1 string", "rangecheck"},
+		{"(a) string", "typecheck"},
+		{"(abc) 3 65 put", "rangecheck"},
+		{"(abc) 0 256 put", "rangecheck"},
+		{"(abc) 0 (A) put", "typecheck"},
+		{"(abc) 2 string copy", "rangecheck"},
+		{"1 (abc) copy", "typecheck"},
+		{"(abc) 1 search", "typecheck"},
+		{"(abc) anchorsearch", "stackunderflow"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			testInterpreter := CreateInterpreter()
+			err := testInterpreter.Run(test.input)
+
+			psErr, ok := err.(*PSError)
+			if !ok || psErr.Name != test.expected {
+				t.Errorf("Expected %s, got %v", test.expected, err)
+			}
+		})
+	}
+}
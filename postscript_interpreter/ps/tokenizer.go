@@ -0,0 +1,701 @@
+package ps
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type TokenType int
+
+// defining the types of token types for the interpreter
+// essentially defining what each thing is and how to handle it
+const (
+	TOKEN_INT TokenType = iota // iota for numbering
+	TOKEN_FLOAT
+	TOKEN_STRING
+	TOKEN_NAME
+	TOKEN_OPERATOR
+	TOKEN_BLOCK_START
+	TOKEN_BLOCK_END
+	TOKEN_BOOL
+	TOKEN_IMMEDIATE // //name, replaced by its value when scanned
+)
+
+// defining the structure of a token
+type Token struct {
+	Type  TokenType
+	Value any
+	Pos   Position // where the token starts in the source
+}
+
+// Position is a location in PostScript source, lines and columns count from 1
+// the zero Position means the location is unknown
+// Source tells apart inputs that have no file, so a position is only matched up with the text it's in
+type Position struct {
+	File   string
+	Source string // the input the position is in: the file name, stringSource, or what RunInput was given
+	Line   int
+	Column int
+}
+
+// the Source of positions in strings that were executed or scanned with token
+const stringSource = "%string"
+
+// formats the position as file:line:column, or line:column when there is no file name
+func (p Position) String() string {
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// reports whether the position is known
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// defining structure of actual tokenizer
+// tokens are read lazily from the reader, so only the token being scanned is held in memory
+type Tokenizer struct {
+	reader  *bufio.Reader
+	file    string // file name recorded in token positions
+	source  string // input recorded in token positions, the file name unless set otherwise
+	offset  int    // number of bytes consumed so far
+	line    int    // line of the next byte
+	column  int    // column of the next byte
+	readErr error  // read failure other than reaching the end of the input
+}
+
+// constructor
+func CreateTokenizer(input string) *Tokenizer {
+	return CreateReaderTokenizer("", strings.NewReader(input))
+}
+
+// constructor for source read from a file or stream, file is the name recorded in token positions
+func CreateReaderTokenizer(file string, reader io.Reader) *Tokenizer {
+	return &Tokenizer{reader: bufio.NewReader(reader), file: file, source: file, line: 1, column: 1}
+}
+
+// constructor for an input that has no file, source is recorded in token positions
+func createSourceTokenizer(source string, input string) *Tokenizer {
+	tokenizer := CreateTokenizer(input)
+	tokenizer.source = source
+	return tokenizer
+}
+
+// Next reads the next token, returning io.EOF once the input is used up
+// the whitespace character ending a name or number is consumed along with it,
+// so data following an operator like currentfile starts right after that one character
+func (t *Tokenizer) Next() (Token, error) {
+	for {
+		t.skipWhitespace()
+		currentChar, ok := t.peek()
+		if !ok {
+			if t.readErr != nil {
+				return Token{}, newPSError("ioerror", "%v", t.readErr)
+			}
+			return Token{}, io.EOF
+		}
+
+		if currentChar == '%' { // ignore comment
+			t.skipComment() // helper function to skip it
+			continue
+		}
+
+		start := t.position()
+		token, err := t.readToken(currentChar)
+		if err != nil {
+			return Token{}, withPosition(err, start)
+		}
+		// tagging the token with where it started in the source
+		token.Pos = start
+		return token, nil
+	}
+}
+
+// tokenize function for breaking up input into
+// tokens interpreter will recognize, reads everything that's left in one go
+func (t *Tokenizer) Tokenize() ([]Token, error) {
+	tokens := []Token{}
+
+	for {
+		token, err := t.Next()
+		if err == io.EOF {
+			return tokens, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+}
+
+// reads one token starting with currentChar
+func (t *Tokenizer) readToken(currentChar byte) (Token, error) {
+	// depending on token type
+	switch {
+
+	case currentChar == '(': // string
+		return t.readString() // helper function to read strings
+
+	case currentChar == '{': // start of code block
+		t.next()
+		// recognized as start token
+		return Token{Type: TOKEN_BLOCK_START}, nil
+
+	case currentChar == '}': // end of code block
+		t.next()
+		return Token{Type: TOKEN_BLOCK_END}, nil
+
+	case currentChar == '[' || currentChar == ']': // array delimiters are operators on their own
+		t.next()
+		return Token{Type: TOKEN_OPERATOR, Value: string(currentChar)}, nil
+
+	case currentChar == '<':
+		following, _ := t.peekAt(1)
+		switch following {
+		case '<': // dictionary delimiter
+			t.next()
+			t.next()
+			return Token{Type: TOKEN_OPERATOR, Value: "<<"}, nil
+		case '~': // ASCII85 string
+			return t.readASCII85String()
+		default: // hex string
+			return t.readHexString()
+		}
+
+	case currentChar == '>':
+		if following, _ := t.peekAt(1); following == '>' { // dictionary delimiter
+			t.next()
+			t.next()
+			return Token{Type: TOKEN_OPERATOR, Value: ">>"}, nil
+		}
+		return Token{}, newPSError("syntaxerror", "unexpected %q", currentChar)
+
+	case currentChar == '/':
+		// variable logic
+		return t.readName(), nil // helper function to read name without '/'
+
+	case IsDigit(currentChar) || currentChar == '-' || currentChar == '+' || currentChar == '.':
+		return t.readNumber()
+
+	case IsRegular(currentChar):
+		return t.readWord(), nil
+
+	default: // a ')' that doesn't close anything
+		return Token{}, newPSError("syntaxerror", "unexpected %q", currentChar)
+	}
+}
+
+// Incomplete reports whether src ends inside a string (literal, hex or ASCII85) or with unclosed braces or brackets
+// used by the REPL to keep reading continuation lines before running the input
+func Incomplete(src string) bool {
+	depth := 0       // open { and [
+	stringDepth := 0 // nested ( inside a string
+
+	for pos := 0; pos < len(src); pos++ {
+		ch := src[pos]
+
+		// inside a string only parentheses and escapes matter
+		if stringDepth > 0 {
+			switch ch {
+			case '\\':
+				pos++ // skipping the escaped character
+			case '(':
+				stringDepth++
+			case ')':
+				stringDepth--
+			}
+			continue
+		}
+
+		switch ch {
+		case '%':
+			for pos < len(src) && src[pos] != '\n' {
+				pos++
+			}
+		case '(':
+			stringDepth++
+		case '<':
+			// hex and ASCII85 strings run to the next '>', << is a dictionary delimiter
+			if pos+1 < len(src) && src[pos+1] == '<' {
+				pos++
+				continue
+			}
+			end := strings.IndexByte(src[pos:], '>')
+			if end < 0 {
+				return true
+			}
+			pos += end
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		}
+	}
+
+	return depth > 0 || stringDepth > 0
+}
+
+// tokenizer helper functions =================================================
+
+// looks at the next byte without consuming it
+func (t *Tokenizer) peek() (byte, bool) {
+	return t.peekAt(0)
+}
+
+// looks n bytes ahead of the next byte without consuming anything
+func (t *Tokenizer) peekAt(n int) (byte, bool) {
+	bytes, err := t.reader.Peek(n + 1)
+	if len(bytes) <= n {
+		if err != nil && err != io.EOF {
+			t.readErr = err
+		}
+		return 0, false
+	}
+	return bytes[n], true
+}
+
+// consumes the next byte, keeping track of the line and column
+func (t *Tokenizer) next() (byte, bool) {
+	ch, err := t.reader.ReadByte()
+	if err != nil {
+		if err != io.EOF {
+			t.readErr = err
+		}
+		return 0, false
+	}
+
+	t.offset++
+	if ch == '\n' {
+		t.line++
+		t.column = 1
+	} else {
+		t.column++
+	}
+	return ch, true
+}
+
+// consumes the next byte if it is ch
+func (t *Tokenizer) skipByte(ch byte) bool {
+	if next, ok := t.peek(); ok && next == ch {
+		t.next()
+		return true
+	}
+	return false
+}
+
+// where the next byte is in the source
+func (t *Tokenizer) position() Position {
+	return Position{File: t.file, Source: t.source, Line: t.line, Column: t.column}
+}
+
+func (t *Tokenizer) skipWhitespace() {
+	for {
+		ch, ok := t.peek()
+		if !ok || !IsWhitespace(ch) {
+			return
+		}
+		t.next()
+	}
+}
+
+func (t *Tokenizer) skipComment() {
+	for {
+		ch, ok := t.peek()
+		if !ok || ch == '\n' {
+			return
+		}
+		t.next()
+	}
+}
+
+// reads a run of regular characters, the text of a name or number
+// a single whitespace character right after it is consumed too
+func (t *Tokenizer) readRegular() string {
+	var text strings.Builder
+	for {
+		ch, ok := t.peek()
+		if !ok || !IsRegular(ch) {
+			break
+		}
+		t.next()
+		text.WriteByte(ch)
+	}
+
+	if ch, ok := t.peek(); ok && IsWhitespace(ch) {
+		t.next()
+		if ch == '\r' {
+			t.skipByte('\n') // \r\n counts as one end of line
+		}
+	}
+	return text.String()
+}
+
+// reads a literal string, ( and ) may nest as long as they are balanced
+// backslash escapes follow the PostScript Language Reference:
+// \n \r \t \b \f \\ \( \) and \ddd octal, a backslash before a newline continues the line,
+// and a backslash before any other character is ignored
+func (t *Tokenizer) readString() (Token, error) {
+	t.next() // for skipping the initial '('
+	var value strings.Builder
+	depth := 1
+
+	for {
+		ch, ok := t.next()
+		if !ok {
+			break
+		}
+
+		switch ch {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				// returning it as a string type token
+				return Token{Type: TOKEN_STRING, Value: value.String()}, nil
+			}
+		case '\\':
+			t.readEscape(&value)
+			continue
+		case '\r':
+			// end of line sequences inside strings are all read as a single newline
+			t.skipByte('\n')
+			ch = '\n'
+		}
+		value.WriteByte(ch)
+	}
+
+	return Token{}, newPSError("syntaxerror", "string unterminated")
+}
+
+// reads the character(s) after a backslash inside a literal string
+func (t *Tokenizer) readEscape(value *strings.Builder) {
+	ch, ok := t.next()
+	if !ok {
+		return
+	}
+
+	switch ch {
+	case 'n':
+		value.WriteByte('\n')
+	case 'r':
+		value.WriteByte('\r')
+	case 't':
+		value.WriteByte('\t')
+	case 'b':
+		value.WriteByte('\b')
+	case 'f':
+		value.WriteByte('\f')
+	case '\\', '(', ')':
+		value.WriteByte(ch)
+	case '\r':
+		// line continuation, \r\n counts as one end of line
+		t.skipByte('\n')
+	case '\n':
+		// line continuation, neither the backslash nor the newline are part of the string
+	default:
+		if IsOctalDigit(ch) {
+			// up to three octal digits, high order overflow is ignored
+			code := int(ch - '0')
+			for digits := 1; digits < 3; digits++ {
+				next, ok := t.peek()
+				if !ok || !IsOctalDigit(next) {
+					break
+				}
+				t.next()
+				code = code*8 + int(next-'0')
+			}
+			value.WriteByte(byte(code))
+			return
+		}
+		// unknown escapes drop the backslash
+		value.WriteByte(ch)
+	}
+}
+
+// reads a hexadecimal string <...>, whitespace is ignored and a missing final digit counts as 0
+func (t *Tokenizer) readHexString() (Token, error) {
+	t.next() // skipping '<'
+	var value strings.Builder
+	var high byte
+	haveHigh := false
+
+	for {
+		ch, ok := t.next()
+		if !ok {
+			break
+		}
+
+		if ch == '>' {
+			if haveHigh {
+				value.WriteByte(high << 4)
+			}
+			return Token{Type: TOKEN_STRING, Value: value.String()}, nil
+		}
+		if IsWhitespace(ch) {
+			continue
+		}
+
+		digit, ok := hexValue(ch)
+		if !ok {
+			return Token{}, newPSError("syntaxerror", "invalid character %q in hex string", ch)
+		}
+		if haveHigh {
+			value.WriteByte(high<<4 | digit)
+		} else {
+			high = digit
+		}
+		haveHigh = !haveHigh
+	}
+
+	return Token{}, newPSError("syntaxerror", "hex string unterminated")
+}
+
+// reads an ASCII base-85 string <~...~>
+// every 5 characters from ! to u encode 4 bytes, z stands for 4 zero bytes,
+// and a final partial group of n characters gives n-1 bytes
+func (t *Tokenizer) readASCII85String() (Token, error) {
+	t.next() // skipping '<~'
+	t.next()
+	var value strings.Builder
+	group := make([]byte, 0, 5)
+
+	for {
+		ch, ok := t.next()
+		if !ok {
+			break
+		}
+
+		switch {
+		case ch == '~':
+			if !t.skipByte('>') {
+				return Token{}, newPSError("syntaxerror", "ASCII85 string must end with ~>")
+			}
+
+			if len(group) == 1 {
+				return Token{}, newPSError("syntaxerror", "ASCII85 string has a single character final group")
+			}
+			if len(group) > 0 {
+				// padding with 'u' then keeping only the bytes the partial group encodes
+				count := len(group) - 1
+				for len(group) < 5 {
+					group = append(group, 'u')
+				}
+				value.Write(decodeASCII85Group(group)[:count])
+			}
+			return Token{Type: TOKEN_STRING, Value: value.String()}, nil
+
+		case IsWhitespace(ch):
+			continue
+
+		case ch == 'z' && len(group) == 0:
+			value.WriteString("\x00\x00\x00\x00")
+
+		case ch >= '!' && ch <= 'u':
+			group = append(group, ch)
+			if len(group) == 5 {
+				value.Write(decodeASCII85Group(group))
+				group = group[:0]
+			}
+
+		default:
+			return Token{}, newPSError("syntaxerror", "invalid character %q in ASCII85 string", ch)
+		}
+	}
+
+	return Token{}, newPSError("syntaxerror", "ASCII85 string unterminated")
+}
+
+// decodes 5 base-85 digits into 4 bytes
+func decodeASCII85Group(group []byte) []byte {
+	var sum uint64
+	for _, ch := range group {
+		sum = sum*85 + uint64(ch-'!')
+	}
+	return []byte{byte(sum >> 24), byte(sum >> 16), byte(sum >> 8), byte(sum)}
+}
+
+// converts a hex digit to its value
+func hexValue(ch byte) (byte, bool) {
+	switch {
+	case ch >= '0' && ch <= '9':
+		return ch - '0', true
+	case ch >= 'a' && ch <= 'f':
+		return ch - 'a' + 10, true
+	case ch >= 'A' && ch <= 'F':
+		return ch - 'A' + 10, true
+	default:
+		return 0, false
+	}
+}
+
+// reads a literal name /name, or an immediately evaluated name //name
+// a name is any run of regular characters, so a lone / is the empty name
+func (t *Tokenizer) readName() Token {
+	t.next() // skip initial '/'
+	if t.skipByte('/') {
+		return Token{Type: TOKEN_IMMEDIATE, Value: t.readRegular()}
+	}
+	return Token{Type: TOKEN_NAME, Value: PSName(t.readRegular())}
+}
+
+// reads a token starting with a digit, sign or '.'
+// anything that doesn't match the number syntax is an executable name instead, like the spec says
+func (t *Tokenizer) readNumber() (Token, error) {
+	text := t.readRegular()
+
+	value, ok, err := parseNumber(text)
+	if err != nil {
+		return Token{}, err
+	}
+	if !ok {
+		return Token{Type: TOKEN_OPERATOR, Value: text}, nil
+	}
+	if _, isInt := value.(int); isInt {
+		return Token{Type: TOKEN_INT, Value: value}, nil
+	}
+	return Token{Type: TOKEN_FLOAT, Value: value}, nil
+}
+
+// parses the PostScript number syntax:
+// signed integers (123, -98, +17), reals (-.002, 34.5, 1e10, 1.0E-5) and radix numbers (16#FFFE, 8#1777)
+// reports false when text is not a number, integers out of 32 bit range become reals
+func parseNumber(text string) (PSConstant, bool, error) {
+	if hash := strings.IndexByte(text, '#'); hash > 0 {
+		return parseRadixNumber(text[:hash], text[hash+1:])
+	}
+
+	if !isNumberSyntax(text) {
+		return nil, false, nil
+	}
+
+	if !strings.ContainsAny(text, ".eE") {
+		if val, err := strconv.ParseInt(text, 10, 64); err == nil && val >= minPSInt && val <= maxPSInt {
+			return int(val), true, nil
+		}
+	}
+
+	// reals, and integers too large to be an int
+	val, err := strconv.ParseFloat(text, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return nil, false, nil
+	}
+	if math.IsInf(val, 0) {
+		return nil, false, newPSError("limitcheck", "%s is too large for a real", text)
+	}
+	return val, true, nil
+}
+
+// parses base#digits, the base is 2 to 36 and the digits are read as an unsigned 32 bit pattern
+func parseRadixNumber(base string, digits string) (PSConstant, bool, error) {
+	radix, err := strconv.Atoi(base)
+	if err != nil || radix < 2 || radix > 36 || len(base) > 2 || digits == "" {
+		return nil, false, nil
+	}
+
+	val, err := strconv.ParseUint(digits, radix, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return nil, false, newPSError("limitcheck", "%s#%s is too large for an integer", base, digits)
+		}
+		return nil, false, nil
+	}
+	if val > math.MaxUint32 {
+		return nil, false, newPSError("limitcheck", "%s#%s is too large for an integer", base, digits)
+	}
+	return int(int32(uint32(val))), true, nil
+}
+
+// checks text against [+-] digits [. digits] [(e|E) [+-] digits], with at least one mantissa digit
+func isNumberSyntax(text string) bool {
+	pos := 0
+	if pos < len(text) && (text[pos] == '+' || text[pos] == '-') {
+		pos++
+	}
+
+	mantissaDigits := 0
+	for pos < len(text) && IsDigit(text[pos]) {
+		pos++
+		mantissaDigits++
+	}
+	if pos < len(text) && text[pos] == '.' {
+		pos++
+		for pos < len(text) && IsDigit(text[pos]) {
+			pos++
+			mantissaDigits++
+		}
+	}
+	if mantissaDigits == 0 {
+		return false
+	}
+
+	if pos < len(text) && (text[pos] == 'e' || text[pos] == 'E') {
+		pos++
+		if pos < len(text) && (text[pos] == '+' || text[pos] == '-') {
+			pos++
+		}
+		exponentDigits := 0
+		for pos < len(text) && IsDigit(text[pos]) {
+			pos++
+			exponentDigits++
+		}
+		if exponentDigits == 0 {
+			return false
+		}
+	}
+
+	return pos == len(text)
+}
+
+// parses through word and assigns value from the name
+// executable names are any run of regular characters, e.g. move-to, x1, $error, @foo
+func (t *Tokenizer) readWord() Token {
+	op := t.readRegular()
+	if op == "true" {
+		return Token{Type: TOKEN_BOOL, Value: true}
+	}
+	if op == "false" {
+		return Token{Type: TOKEN_BOOL, Value: false}
+	}
+
+	return Token{Type: TOKEN_OPERATOR, Value: op}
+}
+
+// some additional helper functions to determine if a symbol is a comment, number, alpha value, or whitespace
+
+func IsComment(ch byte) bool {
+	return ch == '%'
+}
+
+func IsWhitespace(ch byte) bool {
+	return ch == ' ' || ch == '\n' || ch == '\t' || ch == '\r' || ch == '\f' || ch == 0
+}
+
+func IsLetter(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func IsDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+// delimiters end a token without any whitespace needed
+func IsDelimiter(ch byte) bool {
+	return strings.IndexByte("()<>[]{}/%", ch) >= 0
+}
+
+// regular characters are everything that isn't whitespace or a delimiter
+func IsRegular(ch byte) bool {
+	return !IsWhitespace(ch) && !IsDelimiter(ch)
+}
+
+func IsOctalDigit(ch byte) bool {
+	return ch >= '0' && ch <= '7'
+}
@@ -0,0 +1,706 @@
+package ps
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+/*
+ -----------------------------------------------------------------------------
+	Note: Parts of these tests were drafted with the use of Generative AI.
+	All test content and logic has been reviewed and verified manually.
+ -----------------------------------------------------------------------------
+*/
+
+// testing some of the helper functions ==============
+
+func TestIsWhitespace(t *testing.T) {
+	whitespaceChars := []byte{' ', '\n', '\t', '\r'}
+	for _, ch := range whitespaceChars {
+		if !IsWhitespace(ch) {
+			t.Errorf("expected '%c' to be whitespace character", ch)
+		}
+	}
+
+	// additionally testing non-whitespace characters
+	nonWhitespaceChars := []byte{'a', '5', '%', '{'}
+	for _, ch := range nonWhitespaceChars {
+		if IsWhitespace(ch) {
+			t.Errorf("'%c' is not whitespace character", ch)
+		}
+	}
+}
+
+func TestIsComment(t *testing.T) {
+	// making sure % registers as a comment
+	if !IsComment('%') {
+		t.Error("expected '%' to be a comment character")
+	}
+	
+	// additionally testing non-comment characters
+	nonCommentChars := []byte{'a', ' ', '5', '\n'}
+	for _, ch := range nonCommentChars {
+		if IsComment(ch) {
+			t.Errorf("'%c' is not comment character", ch)
+		}
+	}
+}
+
+func TestIsLetter(t *testing.T) {
+	// testing expected letter characters
+	letters := []byte{'a', 'z', 'A', 'Z', 'm'}
+	for _, ch := range letters {
+		if !IsLetter(ch) {
+			t.Errorf("Expected '%c' to be an alphabetic character", ch)
+		}
+	}
+	
+	// testing non-letter characters
+	nonLetters := []byte{'5', ' ', '%', '{', '0', '9'}
+	for _, ch := range nonLetters {
+		if IsLetter(ch) {
+			t.Errorf("'%c' is not alphabetic character", ch)
+		}
+	}
+}
+
+func TestIsDigit(t *testing.T) {
+	digits := []byte{'0', '5', '9'}
+	for _, ch := range digits {
+		if !IsDigit(ch) {
+			t.Errorf("Expected '%c' to be a digit", ch)
+		}
+	}
+	
+	// testing non-digits
+	nonDigits := []byte{'a', ' ', '%', '{'}
+	for _, ch := range nonDigits {
+		if IsDigit(ch) {
+			t.Errorf("'%c' is not a digit", ch)
+		}
+	}
+}
+
+// testing tokenizing ============================
+
+// parsing numbers
+func TestTokenizeNumber(t *testing.T) {
+	tests := []struct {
+		input        string
+		expectedType TokenType
+		expectedVal  any
+	}{
+		{"42", TOKEN_INT, 42},
+		{"3.14", TOKEN_FLOAT, 3.14},
+		{"-5", TOKEN_INT, -5},
+		{"-2.5", TOKEN_FLOAT, -2.5},
+		{"0", TOKEN_INT, 0},
+		{"+3", TOKEN_INT, 3},
+		{".5", TOKEN_FLOAT, 0.5},
+		{"-.002", TOKEN_FLOAT, -0.002},
+		{"5.", TOKEN_FLOAT, 5.0},
+		{"1e10", TOKEN_FLOAT, 1e10},
+		{"1.0E-5", TOKEN_FLOAT, 1.0e-5},
+		{"-3e+2", TOKEN_FLOAT, -300.0},
+		{"16#FF", TOKEN_INT, 255},
+		{"16#ff", TOKEN_INT, 255},
+		{"8#1777", TOKEN_INT, 1023},
+		{"2#1010", TOKEN_INT, 10},
+		{"36#Z", TOKEN_INT, 35},
+		{"16#FFFFFFFF", TOKEN_INT, -1},
+		{"2147483647", TOKEN_INT, 2147483647},
+		{"2147483648", TOKEN_FLOAT, 2147483648.0},
+		{"-2147483649", TOKEN_FLOAT, -2147483649.0},
+		// malformed numbers are names
+		{"1e", TOKEN_OPERATOR, "1e"},
+		{"1.2.3", TOKEN_OPERATOR, "1.2.3"},
+		{"-", TOKEN_OPERATOR, "-"},
+		{".", TOKEN_OPERATOR, "."},
+		{"+-1", TOKEN_OPERATOR, "+-1"},
+		{"12abc", TOKEN_OPERATOR, "12abc"},
+		{"16#FG", TOKEN_OPERATOR, "16#FG"},
+		{"1#1", TOKEN_OPERATOR, "1#1"},
+		{"37#1", TOKEN_OPERATOR, "37#1"},
+		{"-16#F", TOKEN_OPERATOR, "-16#F"},
+		{"16#", TOKEN_OPERATOR, "16#"},
+		{"0x10", TOKEN_OPERATOR, "0x10"},
+		{"1_000", TOKEN_OPERATOR, "1_000"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			tokenizer := CreateTokenizer(test.input)
+			tokens, err := tokenizer.Tokenize()
+
+			if err != nil {
+				t.Fatalf("Tokenize error: %v", err)
+			}
+			if len(tokens) != 1 {
+				t.Fatalf("Expected 1 token, got %d", len(tokens))
+			}
+			if tokens[0].Type != test.expectedType {
+				t.Errorf("Expected type %v, got %v", test.expectedType, tokens[0].Type)
+			}
+			if tokens[0].Value != test.expectedVal {
+				t.Errorf("Expected value %v, got %v", test.expectedVal, tokens[0].Value)
+			}
+		})
+	}
+}
+
+// numbers that can't be represented at all are a limitcheck
+func TestTokenizeNumberLimits(t *testing.T) {
+	inputs := []string{"16#100000000", "1e400", "2#" + strings.Repeat("1", 70)}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := CreateTokenizer(input).Tokenize()
+
+			psErr, ok := err.(*PSError)
+			if !ok || psErr.Name != "limitcheck" {
+				t.Errorf("Expected limitcheck, got %v", err)
+			}
+		})
+	}
+}
+
+// numbers end at delimiters, so no whitespace is needed before them
+func TestTokenizeNumberDelimiters(t *testing.T) {
+	tokens, err := CreateTokenizer("[1 2.5]{3}(s)4/x").Tokenize()
+	if err != nil {
+		t.Fatalf("Tokenize error: %v", err)
+	}
+
+	expected := []any{"[", 1, 2.5, "]", nil, 3, nil, "s", 4, PSName("x")}
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %v", len(expected), tokens)
+	}
+	for index, value := range expected {
+		if tokens[index].Value != value {
+			t.Errorf("token %d: expected %v, got %v", index, value, tokens[index].Value)
+		}
+	}
+}
+
+// parsing strings
+func TestTokenizeStrings(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"(hello world)", "hello world"},
+		{"(test)", "test"},
+		{"()", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			tokenizer := CreateTokenizer(test.input)
+			tokens, err := tokenizer.Tokenize()
+
+			if err != nil {
+				t.Fatalf("Tokenize error: %v", err)
+			}
+			if len(tokens) != 1 {
+				t.Fatalf("Expected 1 token, got %d", len(tokens))
+			}
+			if tokens[0].Type != TOKEN_STRING {
+				t.Errorf("Expected TOKEN_STRING, got %v", tokens[0].Type)
+			}
+			if tokens[0].Value != test.expected {
+				t.Errorf("Expected '%s', got '%v'", test.expected, tokens[0].Value)
+			}
+		})
+	}
+}
+
+// parsing operators
+func TestTokenizeOperators(t *testing.T) {
+	input := "add sub mul"
+	tokenizer := CreateTokenizer(input)
+	tokens, err := tokenizer.Tokenize()
+
+	if err != nil {
+		t.Fatalf("Tokenize error: %v", err)
+	}
+	if len(tokens) != 3 {
+		t.Fatalf("Expected 3 tokens, got %d", len(tokens))
+	}
+
+	expected := []string{"add", "sub", "mul"}
+	for i, exp := range expected {
+		if tokens[i].Type != TOKEN_OPERATOR {
+			t.Errorf("Token %d: expected TOKEN_OPERATOR, got %v", i, tokens[i].Type)
+		}
+		if tokens[i].Value != exp {
+			t.Errorf("Token %d: expected %s, got %v", i, exp, tokens[i].Value)
+		}
+	}
+}
+
+// parsing expressions
+func TestTokenizeSimpleExpression(t *testing.T) {
+	input := "3 4 add"
+	tokenizer := CreateTokenizer(input)
+	tokens, err := tokenizer.Tokenize()
+
+	if err != nil {
+		t.Fatalf("Tokenize error: %v", err)
+	}
+	if len(tokens) != 3 {
+		t.Fatalf("Expected 3 tokens, got %d", len(tokens))
+	}
+
+	// checking first number
+	if tokens[0].Type != TOKEN_INT {
+		t.Errorf("Expected TOKEN_INT for first token, got %v", tokens[0].Type)
+	}
+	if tokens[0].Value != 3 {
+		t.Errorf("Expected 3, got %v", tokens[0].Value)
+	}
+
+	// checking second number
+	if tokens[1].Type != TOKEN_INT {
+		t.Errorf("Expected TOKEN_INT for second token, got %v", tokens[1].Type)
+	}
+	if tokens[1].Value != 4 {
+		t.Errorf("Expected 4, got %v", tokens[1].Value)
+	}
+
+	// checking third number
+	if tokens[2].Type != TOKEN_OPERATOR {
+		t.Errorf("Expected TOKEN_OPERATOR for third token, got %v", tokens[2].Type)
+	}
+	if tokens[2].Value != "add" {
+		t.Errorf("Expected 'add', got %v", tokens[2].Value)
+	}
+}
+
+// parsing procedures
+func TestTokenizeProcedure(t *testing.T) {
+	input := "{ dup mul }"
+	tokenizer := CreateTokenizer(input)
+	tokens, err := tokenizer.Tokenize()
+
+	if err != nil {
+		t.Fatalf("Tokenize error: %v", err)
+	}
+	if len(tokens) != 4 { // braces count in tokens
+		t.Fatalf("Expected 4 tokens, got %d", len(tokens))
+	}
+
+	if tokens[0].Type != TOKEN_BLOCK_START {
+		t.Errorf("Expected TOKEN_BLOCK_START, got %v", tokens[0].Type)
+	}
+	if tokens[1].Type != TOKEN_OPERATOR || tokens[1].Value != "dup" {
+		t.Errorf("Expected 'dup' operator")
+	}
+	if tokens[2].Type != TOKEN_OPERATOR || tokens[2].Value != "mul" {
+		t.Errorf("Expected 'mul' operator")
+	}
+	if tokens[3].Type != TOKEN_BLOCK_END {
+		t.Errorf("Expected TOKEN_BLOCK_END, got %v", tokens[3].Type)
+	}
+}
+
+// parsing comments
+func TestTokenizeComment(t *testing.T) {
+	input := "3 % this is a comment\n4 add"
+	tokenizer := CreateTokenizer(input)
+	tokens, err := tokenizer.Tokenize()
+
+	if err != nil {
+		t.Fatalf("Tokenize error: %v", err)
+	}
+
+	// comment should be ignored, resulting in: 3, 4, add
+	if len(tokens) != 3 {
+		t.Fatalf("Expected 3 tokens (comment ignored), got %d", len(tokens))
+	}
+
+	// cross checking expected tokens
+	if tokens[0].Type != TOKEN_INT || tokens[0].Value != 3 {
+		t.Errorf("Expected INT 3, got %v %v", tokens[0].Type, tokens[0].Value)
+	}
+	if tokens[1].Type != TOKEN_INT || tokens[1].Value != 4 {
+		t.Errorf("Expected INT 4, got %v %v", tokens[1].Type, tokens[1].Value)
+	}
+	if tokens[2].Type != TOKEN_OPERATOR || tokens[2].Value != "add" {
+		t.Errorf("Expected OPERATOR 'add', got %v %v", tokens[2].Type, tokens[2].Value)
+	}
+}
+
+// parsing names
+func TestTokenizeNames(t *testing.T) {
+	input := "/x /myvar /test123"
+	tokenizer := CreateTokenizer(input)
+	tokens, err := tokenizer.Tokenize()
+
+	if err != nil {
+		t.Fatalf("Tokenize error: %v", err)
+	}
+	if len(tokens) != 3 {
+		t.Fatalf("Expected 3 tokens, got %d", len(tokens))
+	}
+
+	expected := []PSName{"x", "myvar", "test123"}
+	for i, exp := range expected {
+		if tokens[i].Type != TOKEN_NAME {
+			t.Errorf("Token %d: expected TOKEN_NAME, got %v", i, tokens[i].Type)
+		}
+		if tokens[i].Value != exp {
+			t.Errorf("Token %d: expected %v, got %v", i, exp, tokens[i].Value)
+		}
+	}
+}
+
+// names are made of any regular characters, not just letters
+func TestTokenizeSpecialNames(t *testing.T) {
+	tests := []struct {
+		input        string
+		expectedType TokenType
+		expectedVal  any
+	}{
+		{"move-to", TOKEN_OPERATOR, "move-to"},
+		{"x1", TOKEN_OPERATOR, "x1"},
+		{"$error", TOKEN_OPERATOR, "$error"},
+		{".internal", TOKEN_OPERATOR, ".internal"},
+		{"@foo", TOKEN_OPERATOR, "@foo"},
+		{"a=b", TOKEN_OPERATOR, "a=b"},
+		{"/move-to", TOKEN_NAME, PSName("move-to")},
+		{"/$error", TOKEN_NAME, PSName("$error")},
+		{"/1st", TOKEN_NAME, PSName("1st")},
+		{"/", TOKEN_NAME, PSName("")},
+		{"//add", TOKEN_IMMEDIATE, "add"},
+		{"//x-1", TOKEN_IMMEDIATE, "x-1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			tokens, err := CreateTokenizer(test.input).Tokenize()
+			if err != nil {
+				t.Fatalf("Tokenize error: %v", err)
+			}
+			if len(tokens) != 1 {
+				t.Fatalf("Expected 1 token, got %v", tokens)
+			}
+			if tokens[0].Type != test.expectedType {
+				t.Errorf("Expected type %v, got %v", test.expectedType, tokens[0].Type)
+			}
+			if tokens[0].Value != test.expectedVal {
+				t.Errorf("Expected %v, got %v", test.expectedVal, tokens[0].Value)
+			}
+		})
+	}
+}
+
+// names stop at delimiters
+func TestTokenizeNameDelimiters(t *testing.T) {
+	tokens, err := CreateTokenizer("/a/b{c}d(s)e[f]").Tokenize()
+	if err != nil {
+		t.Fatalf("Tokenize error: %v", err)
+	}
+
+	expected := []any{PSName("a"), PSName("b"), nil, "c", nil, "d", "s", "e", "[", "f", "]"}
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %v", len(expected), tokens)
+	}
+	for index, value := range expected {
+		if tokens[index].Value != value {
+			t.Errorf("token %d: expected %v, got %v", index, value, tokens[index].Value)
+		}
+	}
+}
+
+// stray closing delimiters are a syntaxerror instead of being skipped
+func TestTokenizeInvalidInput(t *testing.T) {
+	inputs := []string{")", "1 2 )", ">", "1 > 2"}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := CreateTokenizer(input).Tokenize()
+
+			psErr, ok := err.(*PSError)
+			if !ok || psErr.Name != "syntaxerror" {
+				t.Errorf("Expected syntaxerror, got %v", err)
+			}
+		})
+	}
+}
+
+// parsing booleans
+func TestTokenizeBooleans(t *testing.T) {
+	input := "true false"
+	tokenizer := CreateTokenizer(input)
+	tokens, err := tokenizer.Tokenize()
+
+	if err != nil {
+		t.Fatalf("Tokenize error: %v", err)
+	}
+	if len(tokens) != 2 {
+		t.Fatalf("Expected 2 tokens, got %d", len(tokens))
+	}
+
+	// true
+	if tokens[0].Type != TOKEN_BOOL {
+		t.Errorf("Expected TOKEN_BOOL for 'true', got %v", tokens[0].Type)
+	}
+	if tokens[0].Value != true {
+		t.Errorf("Expected true, got %v", tokens[0].Value)
+	}
+
+	// false
+	if tokens[1].Type != TOKEN_BOOL {
+		t.Errorf("Expected TOKEN_BOOL for 'false', got %v", tokens[1].Type)
+	}
+	if tokens[1].Value != false {
+		t.Errorf("Expected false, got %v", tokens[1].Value)
+	}
+}
+
+func TestTokenizeEquals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"=", "="},
+		{"==", "=="},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			tokenizer := CreateTokenizer(test.input)
+			tokens, err := tokenizer.Tokenize()
+
+			if err != nil {
+				t.Fatalf("Tokenize error: %v", err)
+			}
+			if len(tokens) != 1 {
+				t.Fatalf("Expected 1 token, got %d", len(tokens))
+			}
+			if tokens[0].Type != TOKEN_OPERATOR {
+				t.Errorf("Expected TOKEN_OPERATOR, got %v", tokens[0].Type)
+			}
+			if tokens[0].Value != test.expected {
+				t.Errorf("Expected '%s', got '%v'", test.expected, tokens[0].Value)
+			}
+		})
+	}
+}
+
+// parsing array delimiters
+func TestTokenizeArrayBrackets(t *testing.T) {
+	tokenizer := CreateTokenizer("[1 2]")
+	tokens, err := tokenizer.Tokenize()
+
+	if err != nil {
+		t.Fatalf("Tokenize error: %v", err)
+	}
+	if len(tokens) != 4 {
+		t.Fatalf("Expected 4 tokens, got %d", len(tokens))
+	}
+	if tokens[0].Type != TOKEN_OPERATOR || tokens[0].Value != "[" {
+		t.Errorf("Expected [ operator, got %v", tokens[0].Value)
+	}
+	if tokens[3].Type != TOKEN_OPERATOR || tokens[3].Value != "]" {
+		t.Errorf("Expected ] operator, got %v", tokens[3].Value)
+	}
+}
+
+// parsing dictionary delimiters
+func TestTokenizeDictDelimiters(t *testing.T) {
+	tokenizer := CreateTokenizer("<</a 1>>")
+	tokens, err := tokenizer.Tokenize()
+
+	if err != nil {
+		t.Fatalf("Tokenize error: %v", err)
+	}
+	if len(tokens) != 4 {
+		t.Fatalf("Expected 4 tokens, got %d", len(tokens))
+	}
+	if tokens[0].Type != TOKEN_OPERATOR || tokens[0].Value != "<<" {
+		t.Errorf("Expected << operator, got %v", tokens[0].Value)
+	}
+	if tokens[3].Type != TOKEN_OPERATOR || tokens[3].Value != ">>" {
+		t.Errorf("Expected >> operator, got %v", tokens[3].Value)
+	}
+}
+
+// parsing the escape sequences and nesting rules of literal strings
+func TestTokenizeStringSyntax(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"nested parens", "(a(b)c)", "a(b)c"},
+		{"escaped parens", "(a\\(b)", "a(b"},
+		{"newline and tab", "(a\\nb\\tc)", "a\nb\tc"},
+		{"return backspace formfeed", "(\\r\\b\\f)", "\r\b\f"},
+		{"backslash", "(a\\\\b)", "a\\b"},
+		{"octal", "(\\101\\102)", "AB"},
+		{"short octal", "(\\0a)", "\x00a"},
+		{"octal stops at three digits", "(\\1012)", "A2"},
+		{"line continuation", "(ab\\\ncd)", "abcd"},
+		{"crlf continuation", "(ab\\\r\ncd)", "abcd"},
+		{"crlf read as newline", "(a\r\nb)", "a\nb"},
+		{"unknown escape", "(\\q)", "q"},
+		{"hex string", "<48656c6c6f>", "Hello"},
+		{"hex with whitespace", "<48 65\n6C 6c 6F>", "Hello"},
+		{"hex odd digit count", "<414>", "A@"},
+		{"empty hex", "<>", ""},
+		{"ascii85", "<~87cURD]i,\"Ebo80~>", "Hello World!"},
+		{"ascii85 zero group", "<~z~>", "\x00\x00\x00\x00"},
+		{"ascii85 partial group", "<~5l~>", "A"},
+		{"ascii85 with whitespace", "<~87cUR D]i,\n\"Ebo80~>", "Hello World!"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokens, err := CreateTokenizer(test.input).Tokenize()
+			if err != nil {
+				t.Fatalf("Tokenize error: %v", err)
+			}
+			if len(tokens) != 1 || tokens[0].Type != TOKEN_STRING {
+				t.Fatalf("Expected a single string token, got %v", tokens)
+			}
+			if tokens[0].Value != test.expected {
+				t.Errorf("Expected %q, got %q", test.expected, tokens[0].Value)
+			}
+		})
+	}
+}
+
+func TestTokenizeStringErrors(t *testing.T) {
+	inputs := []string{"(abc", "(a(b)", "<414", "<41G2>", "<~87cUR", "<~87~x>", "<~8~>", "<~87cU{~>"}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			_, err := CreateTokenizer(input).Tokenize()
+
+			psErr, ok := err.(*PSError)
+			if !ok || psErr.Name != "syntaxerror" {
+				t.Errorf("Expected syntaxerror, got %v", err)
+			}
+		})
+	}
+}
+
+// checking when the REPL should wait for more input
+func TestIncomplete(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"1 2 add", false},
+		{"/f {", true},
+		{"/f { dup mul }", false},
+		{"{ { 1 }", true},
+		{"[1 2", true},
+		{"[1 2]", false},
+		{"(unterminated", true},
+		{"(a (nested) string)", false},
+		{"(a (nested string)", true},
+		{"(escaped \\) paren", true},
+		{"(brace { in string)", false},
+		{"% comment with {", false},
+		{"<48656c", true},
+		{"<48656c6c6f>", false},
+		{"<< /a 1 >>", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			if Incomplete(test.input) != test.expected {
+				t.Errorf("Incomplete(%q): expected %v", test.input, test.expected)
+			}
+		})
+	}
+}
+
+// every token records the line and column it starts at
+func TestTokenizePositions(t *testing.T) {
+	tokens, err := CreateReaderTokenizer("prog.ps", strings.NewReader("1 add\n  (two\nlines) /x\n{dup}")).Tokenize()
+	if err != nil {
+		t.Fatalf("Tokenize error: %v", err)
+	}
+
+	// a file is its own source
+	at := func(line, column int) Position {
+		return Position{File: "prog.ps", Source: "prog.ps", Line: line, Column: column}
+	}
+	expected := []Position{
+		at(1, 1), at(1, 3),
+		at(2, 3), at(3, 8),
+		at(4, 1), at(4, 2), at(4, 5),
+	}
+	if len(tokens) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %v", len(expected), tokens)
+	}
+	for index, pos := range expected {
+		if tokens[index].Pos != pos {
+			t.Errorf("token %d: expected %v, got %v", index, pos, tokens[index].Pos)
+		}
+	}
+}
+
+func TestTokenizeErrorPosition(t *testing.T) {
+	_, err := CreateTokenizer("1 2\n  <4G>").Tokenize()
+
+	psErr, ok := err.(*PSError)
+	if !ok {
+		t.Fatalf("Expected a PSError, got %v", err)
+	}
+	if psErr.Pos != (Position{Line: 2, Column: 3}) {
+		t.Errorf("Expected error at 2:3, got %v", psErr.Pos)
+	}
+}
+
+// tokens are read one at a time, only as far into the input as needed
+func TestTokenizerNext(t *testing.T) {
+	reader := &countingReader{reader: strings.NewReader("1 /two (three)" + strings.Repeat(" ", 100000) + "four")}
+	tokenizer := CreateReaderTokenizer("", reader)
+
+	expected := []any{1, PSName("two"), "three", "four"}
+	for index, value := range expected {
+		token, err := tokenizer.Next()
+		if err != nil {
+			t.Fatalf("Next error: %v", err)
+		}
+		if token.Value != value {
+			t.Errorf("token %d: expected %v, got %v", index, value, token.Value)
+		}
+		if index == 0 && reader.read > 4096 {
+			t.Errorf("Expected the first token to be read without reading the whole input, read %d bytes", reader.read)
+		}
+	}
+
+	if _, err := tokenizer.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF at the end of the input, got %v", err)
+	}
+}
+
+func TestTokenizerReadError(t *testing.T) {
+	reader := io.MultiReader(strings.NewReader("1 2 "), iotest.ErrReader(errors.New("disk on fire")))
+	tokens, err := CreateReaderTokenizer("", reader).Tokenize()
+
+	psErr, ok := err.(*PSError)
+	if !ok || psErr.Name != "ioerror" {
+		t.Errorf("Expected ioerror, got %v (tokens %v)", err, tokens)
+	}
+}
+
+// keeps track of how many bytes have been read from the underlying reader
+type countingReader struct {
+	reader io.Reader
+	read   int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += n
+	return n, err
+}
@@ -0,0 +1,157 @@
+package ps
+
+// defining types so they can be categorized later in the tokenizing + interpretation stages
+
+type PSConstant interface{} // represents any PS value
+
+// for literal names like \x
+type PSName string
+
+// the access attribute of arrays, strings and dictionaries, from most to least permissive
+// the zero value is unlimited, readonly, executeonly and noaccess only ever reduce it
+type accessLevel int
+
+const (
+	unlimitedAccess accessLevel = iota
+	readOnlyAccess
+	executeOnlyAccess
+	noAccess
+)
+
+// whether get, forall and the like may read the contents
+func (a accessLevel) canRead() bool {
+	return a <= readOnlyAccess
+}
+
+// whether put, def and the like may change the contents
+func (a accessLevel) canWrite() bool {
+	return a == unlimitedAccess
+}
+
+// whether an executable object may be run
+func (a accessLevel) canExecute() bool {
+	return a <= executeOnlyAccess
+}
+
+// for executable names, e.g. a literal name converted with cvx
+// executing one looks the name up and executes the value found
+type PSExecName string
+
+// for arrays and code blocks, a procedure is just an executable array
+// Items is shared between copies, so put and getinterval see the same storage like PostScript expects
+// Executable is the PostScript literal/executable attribute, procedures built from { } are executable
+// CapturedDicts is the dict stack as it was when a lexically scoped procedure was created, which it runs in,
+// nil for dynamically scoped procedures
+// the dictionaries themselves are shared, so later definitions in them are still seen
+// access belongs to this reference rather than the storage, like the executable attribute
+type PSArray struct {
+	Items         []PSConstant
+	CapturedDicts []*PSDict
+	Executable    bool
+	access        accessLevel
+	storage       *arrayStorage // identifies the storage Items lives in, nil for arrays not made by CreateArray
+	start         int           // where Items begins within the storage
+	positions     []Position    // source position of each item for procedures read from source, nil otherwise
+}
+
+// the identity of the storage shared by an array, its copies and its subarrays
+// it isn't zero sized so every one has its own address, which empty arrays' Items don't
+type arrayStorage struct {
+	_ byte
+}
+
+// creates an array object with its own storage holding items
+func CreateArray(items []PSConstant) PSArray {
+	return PSArray{Items: items, storage: &arrayStorage{}}
+}
+
+// the part of the array from start to end, sharing its storage and keeping its attributes
+func (a PSArray) subarray(start, end int) PSArray {
+	a.Items = a.Items[start:end:end]
+	if len(a.positions) > 0 {
+		a.positions = a.positions[start:end]
+	}
+	a.start += start
+	return a
+}
+
+// returns the source position of the item at index, the zero Position if it isn't known
+func (a PSArray) positionOf(index int) Position {
+	if index < len(a.positions) {
+		return a.positions[index]
+	}
+	return Position{}
+}
+
+// for strings, which are mutable arrays of bytes in PostScript
+// Bytes is shared between copies like PSArray's Items, so put and putinterval change the string everywhere it's
+// referenced and substrings from getinterval see the same storage
+// an Executable string (made with cvx) is run as a program when it's executed
+type PSString struct {
+	Bytes      []byte
+	Executable bool
+	access     accessLevel
+}
+
+// creates a string object holding a copy of text
+func CreateString(text string) PSString {
+	return PSString{Bytes: []byte(text)}
+}
+
+// the contents of the string
+func (s PSString) String() string {
+	return string(s.Bytes)
+}
+
+// the part of the string from start to end, sharing its storage and keeping its attributes
+func (s PSString) substring(start, end int) PSString {
+	s.Bytes = s.Bytes[start:end:end]
+	return s
+}
+
+// the null object, e.g. the initial contents of an array
+type PSNull struct{}
+
+func (PSNull) String() string {
+	return "null"
+}
+
+// the mark object, pushed by [ and used to find where an array begins
+type PSMark struct{}
+
+func (PSMark) String() string {
+	return "-mark-"
+}
+
+// file objects, currently the source a program is being read from as returned by currentfile
+// a nil tokenizer is a file with nothing left to read
+type PSFile struct {
+	tokenizer *Tokenizer
+}
+
+func (*PSFile) String() string {
+	return "-file-"
+}
+
+// defining the dictionary
+// keys are stored in the form dictKey gives them, names as plain Go strings so they can be looked up by text
+// access is shared by every reference to the dictionary, unlike for arrays and strings
+type PSDict struct {
+	items     map[PSConstant]PSConstant
+	capacity  int
+	access    accessLevel
+	arrayKeys map[arrayKey]PSArray // arrays used as keys, kept to give them back to forall and ==
+	sequence  map[PSConstant]int   // when each key that has no order by value was first stored, see sortedKeys
+	stored    int                  // how many of those keys have been stored, numbering the next one
+}
+
+// built-in operators, stored as values in systemdict
+type PSOperator struct {
+	Name string
+	Fn   func(*Interpreter) error
+}
+
+// operators print the way PostScript shows them with ==, e.g. --add--
+func (op *PSOperator) String() string {
+	return "--" + op.Name + "--"
+}